@@ -1,18 +1,35 @@
 package tyapi
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"github.com/levenlabs/go-typeform/tyform"
+	"gopkg.in/mgo.v2/bson"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
-var version = "v0.4"
+// APIToken is used by the package-level functions if DefaultClient wasn't
+// created with its own token
 var APIToken string
-var errRmptyToken = errors.New("Empty APIToken")
-var client httpClient = http.DefaultClient
+
+// DefaultClient is used by the package-level functions, like Create
+var DefaultClient = NewClient()
+
+var errEmptyToken = errors.New("Empty APIToken")
+
+// defaultClient returns DefaultClient, falling back to APIToken if it doesn't
+// have a token
+func defaultClient() *Client {
+	if DefaultClient.token != "" || APIToken == "" {
+		return DefaultClient
+	}
+	c := *DefaultClient
+	c.token = APIToken
+	return &c
+}
 
 type URLs struct {
 	ID      string `json:"id"`
@@ -25,57 +42,178 @@ type CreateResult struct {
 	URLs []URLs `json:"urls"`
 }
 
-// Creates a survey on typeform. Returns an `Error` if we get one.
+// Creates a survey on typeform using the DefaultClient. Returns an `Error` if
+// we get one.
 func Create(f *tyform.Form) (*CreateResult, error) {
-	if APIToken == "" {
-		return nil, errRmptyToken
-	}
+	return defaultClient().Create(f)
+}
 
-	b, err := json.Marshal(f)
-	if err != nil {
+// CreateContext is like Create but the request is canceled along with ctx
+func CreateContext(ctx context.Context, f *tyform.Form) (*CreateResult, error) {
+	return defaultClient().CreateContext(ctx, f)
+}
+
+// Create creates a survey on typeform. Returns an `Error` if we get one.
+func (c *Client) Create(f *tyform.Form) (*CreateResult, error) {
+	return c.CreateContext(context.Background(), f)
+}
+
+// CreateContext is like Create but the request is canceled along with ctx
+func (c *Client) CreateContext(ctx context.Context, f *tyform.Form) (*CreateResult, error) {
+	res := &CreateResult{}
+	if err := c.do(ctx, "POST", "/forms", f, http.StatusCreated, res); err != nil {
 		return nil, err
 	}
-	u := fmt.Sprintf("https://api.typeform.io/%s/forms", version)
-	req, err := http.NewRequest("POST", u, bytes.NewBuffer(b))
-	if err != nil {
-		return nil, err
+	return res, nil
+}
+
+// FormResult is a form returned from typeform along with its ID
+type FormResult struct {
+	ID          string `json:"id"                bson:"_id"`
+	tyform.Form `bson:",inline"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (r *FormResult) UnmarshalJSON(b []byte) error {
+	id := &struct {
+		ID string `json:"id"`
+	}{}
+	if err := json.Unmarshal(b, id); err != nil {
+		return err
 	}
-	req.Header.Set("X-API-TOKEN", APIToken)
+	r.ID = id.ID
+	return json.Unmarshal(b, &r.Form)
+}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
+// SetBSON implements the bson.Setter interface
+func (r *FormResult) SetBSON(raw bson.Raw) error {
+	id := &struct {
+		ID string `bson:"_id"`
+	}{}
+	if err := raw.Unmarshal(id); err != nil {
+		return err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusCreated {
-		errRes := &Error{}
-		dec := json.NewDecoder(resp.Body)
-		if err = dec.Decode(errRes); err != nil {
-			return nil, fmt.Errorf("unexpected response from /forms: %s", resp.Status)
-		}
-		return nil, *errRes
+	r.ID = id.ID
+	return raw.Unmarshal(&r.Form)
+}
+
+// ListOptions are passed to list endpoints to select a page of results. Zero
+// values are left for typeform to default.
+type ListOptions struct {
+	Page     int
+	PageSize int
+}
+
+// values returns the options as query parameters
+func (o ListOptions) values() url.Values {
+	v := url.Values{}
+	if o.Page > 0 {
+		v.Set("page", strconv.Itoa(o.Page))
+	}
+	if o.PageSize > 0 {
+		v.Set("page_size", strconv.Itoa(o.PageSize))
 	}
+	return v
+}
 
-	res := &CreateResult{}
-	dec := json.NewDecoder(resp.Body)
-	if err = dec.Decode(res); err != nil {
+// withQuery appends the query parameters to the path, if there are any
+func withQuery(path string, v url.Values) string {
+	if len(v) == 0 {
+		return path
+	}
+	return path + "?" + v.Encode()
+}
+
+// FormList is a single page of forms returned by ListForms
+type FormList struct {
+	TotalItems int          `json:"total_items"`
+	PageCount  int          `json:"page_count"`
+	Items      []FormResult `json:"items"`
+}
+
+func formPath(id string) string {
+	return "/forms/" + url.PathEscape(id)
+}
+
+// GetForm fetches the form with the given ID
+func (c *Client) GetForm(id string) (*FormResult, error) {
+	return c.GetFormContext(context.Background(), id)
+}
+
+// GetFormContext is like GetForm but the request is canceled along with ctx
+func (c *Client) GetFormContext(ctx context.Context, id string) (*FormResult, error) {
+	res := &FormResult{}
+	if err := c.do(ctx, "GET", formPath(id), nil, http.StatusOK, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListForms fetches a single page of forms
+func (c *Client) ListForms(o ListOptions) (*FormList, error) {
+	return c.ListFormsContext(context.Background(), o)
+}
+
+// ListFormsContext is like ListForms but the request is canceled along with
+// ctx
+func (c *Client) ListFormsContext(ctx context.Context, o ListOptions) (*FormList, error) {
+	res := &FormList{}
+	if err := c.do(ctx, "GET", withQuery("/forms", o.values()), nil, http.StatusOK, res); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-type Error struct {
-	ErrorType   string `json:"error"`
-	Field       string `json:"field"`
-	Description string `json:"description"`
+// FormIterator iterates over all of the forms returned by ListForms
+type FormIterator struct {
+	*Iterator
+}
+
+// Form returns the current form. It's only valid after Next returns true.
+func (it FormIterator) Form() *FormResult {
+	f, _ := it.Value().(*FormResult)
+	return f
+}
+
+// IterateForms returns a FormIterator that fetches every page of forms
+// starting at the one described by o
+func (c *Client) IterateForms(ctx context.Context, o ListOptions) FormIterator {
+	return FormIterator{NewIterator(ctx, o, func(ctx context.Context, o ListOptions) ([]interface{}, *ListOptions, error) {
+		res, err := c.ListFormsContext(ctx, o)
+		if err != nil {
+			return nil, nil, err
+		}
+		items := make([]interface{}, len(res.Items))
+		for i := range res.Items {
+			items[i] = &res.Items[i]
+		}
+		return items, nextPage(o, res.PageCount), nil
+	})}
+}
+
+// UpdateForm replaces the form with the given ID with f and returns the
+// updated form
+func (c *Client) UpdateForm(id string, f *tyform.Form) (*FormResult, error) {
+	return c.UpdateFormContext(context.Background(), id, f)
+}
+
+// UpdateFormContext is like UpdateForm but the request is canceled along with
+// ctx
+func (c *Client) UpdateFormContext(ctx context.Context, id string, f *tyform.Form) (*FormResult, error) {
+	res := &FormResult{}
+	if err := c.do(ctx, "PUT", formPath(id), f, http.StatusOK, res); err != nil {
+		return nil, err
+	}
+	return res, nil
 }
 
-func (e Error) Error() string {
-	return fmt.Sprintf("%s on field %s: %s", e.ErrorType, e.Field, e.Description)
+// DeleteForm deletes the form with the given ID
+func (c *Client) DeleteForm(id string) error {
+	return c.DeleteFormContext(context.Background(), id)
 }
 
-// httpClient is an interface that describes http.Client so we can override what
-// client we use in testing
-type httpClient interface {
-	Do(*http.Request) (*http.Response, error)
+// DeleteFormContext is like DeleteForm but the request is canceled along with
+// ctx
+func (c *Client) DeleteFormContext(ctx context.Context, id string) error {
+	return c.do(ctx, "DELETE", formPath(id), nil, http.StatusNoContent, nil)
 }
@@ -2,10 +2,12 @@ package tyapi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"github.com/levenlabs/go-typeform/tyform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -15,9 +17,14 @@ import (
 type testClient struct {
 	Body       io.ReadCloser
 	StatusCode int
+	Req        *http.Request
 }
 
 func (t *testClient) Do(r *http.Request) (*http.Response, error) {
+	t.Req = r
+	if err := r.Context().Err(); err != nil {
+		return nil, err
+	}
 	resp := &http.Response{
 		StatusCode: t.StatusCode,
 		Body:       t.Body,
@@ -52,10 +59,10 @@ func TestCreate(t *T) {
 			"version": "v0.4"
 		}]
 	}`)
-	client = &testClient{
+	DefaultClient = NewClient(WithHTTPClient(&testClient{
 		Body:       ioutil.NopCloser(bytes.NewBuffer(j)),
 		StatusCode: http.StatusCreated,
-	}
+	}))
 
 	res, err := Create(f)
 	require.Nil(t, err)
@@ -70,10 +77,10 @@ func TestCreate(t *T) {
 		"field": "test_field",
 		"description": "this is an error"
 	}`)
-	client = &testClient{
+	DefaultClient = NewClient(WithHTTPClient(&testClient{
 		Body:       ioutil.NopCloser(bytes.NewBuffer(j)),
 		StatusCode: http.StatusBadRequest,
-	}
+	}))
 
 	_, err = Create(f)
 	require.NotNil(t, err)
@@ -85,3 +92,127 @@ func TestCreate(t *T) {
 	assert.Equal(t, "test_field", errRes.Field)
 	assert.Equal(t, "this is an error", errRes.Description)
 }
+
+func TestClientCreate(t *T) {
+	f := &tyform.Form{}
+	tc := &testClient{
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`{"id":"random"}`)),
+		StatusCode: http.StatusCreated,
+	}
+	c := NewClient(
+		WithToken("token"),
+		WithBaseURL("http://localhost/"),
+		WithVersion("v1"),
+		WithUserAgent("ua"),
+		WithHTTPClient(tc),
+	)
+	res, err := c.Create(f)
+	require.Nil(t, err)
+	assert.Equal(t, "random", res.ID)
+
+	require.NotNil(t, tc.Req)
+	assert.Equal(t, "POST", tc.Req.Method)
+	assert.Equal(t, "http://localhost/v1/forms", tc.Req.URL.String())
+	assert.Equal(t, "token", tc.Req.Header.Get("X-API-TOKEN"))
+	assert.Equal(t, "ua", tc.Req.Header.Get("User-Agent"))
+
+	_, err = NewClient(WithHTTPClient(tc)).Create(f)
+	assert.Equal(t, errEmptyToken, err)
+}
+
+func TestCreateContext(t *T) {
+	tc := &testClient{
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`{"id":"random"}`)),
+		StatusCode: http.StatusCreated,
+	}
+	c := NewClient(WithToken("token"), WithHTTPClient(tc))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.CreateContext(ctx, &tyform.Form{})
+	assert.Equal(t, context.Canceled, err)
+	require.NotNil(t, tc.Req)
+	assert.Equal(t, ctx, tc.Req.Context())
+}
+
+func TestGetForm(t *T) {
+	sc := &seqClient{
+		Responses: []seqResponse{
+			{StatusCode: http.StatusOK, Body: `{
+				"id": "abc",
+				"title": "Form",
+				"fields": [
+					{"type": "yes_no", "question": "Yes?"}
+				]
+			}`},
+		},
+	}
+	c := NewClient(WithToken("test"), WithHTTPClient(sc))
+	res, err := c.GetForm("abc")
+	require.Nil(t, err)
+	require.Len(t, sc.Reqs, 1)
+	assert.Equal(t, "GET", sc.Reqs[0].Method)
+	assert.Equal(t, "/v0.4/forms/abc", sc.Reqs[0].URL.Path)
+
+	assert.Equal(t, "abc", res.ID)
+	assert.Equal(t, "Form", res.Title)
+	require.Len(t, res.Fields, 1)
+	yn, ok := res.Fields[0].(*tyform.YesNo)
+	require.True(t, ok)
+	assert.Equal(t, "Yes?", yn.Question)
+
+	b, err := bson.Marshal(res)
+	require.Nil(t, err)
+	nres := &FormResult{}
+	require.Nil(t, bson.Unmarshal(b, nres))
+	assert.EqualValues(t, res, nres)
+}
+
+func TestListForms(t *T) {
+	sc := &seqClient{
+		Responses: []seqResponse{
+			{StatusCode: http.StatusOK, Body: `{
+				"total_items": 3,
+				"page_count": 2,
+				"items": [
+					{"id": "a", "title": "A"},
+					{"id": "b", "title": "B"}
+				]
+			}`},
+		},
+	}
+	c := NewClient(WithToken("test"), WithHTTPClient(sc))
+	res, err := c.ListForms(ListOptions{Page: 1, PageSize: 2})
+	require.Nil(t, err)
+	require.Len(t, sc.Reqs, 1)
+	assert.Equal(t, "page=1&page_size=2", sc.Reqs[0].URL.RawQuery)
+
+	assert.Equal(t, 3, res.TotalItems)
+	assert.Equal(t, 2, res.PageCount)
+	require.Len(t, res.Items, 2)
+	assert.Equal(t, "a", res.Items[0].ID)
+	assert.Equal(t, "A", res.Items[0].Title)
+	assert.Equal(t, "b", res.Items[1].ID)
+}
+
+func TestUpdateDeleteForm(t *T) {
+	sc := &seqClient{
+		Responses: []seqResponse{
+			{StatusCode: http.StatusOK, Body: `{"id": "abc", "title": "New"}`},
+			{StatusCode: http.StatusNoContent},
+		},
+	}
+	c := NewClient(WithToken("test"), WithHTTPClient(sc))
+	f := &tyform.Form{}
+	f.Title = "New"
+	res, err := c.UpdateForm("abc", f)
+	require.Nil(t, err)
+	assert.Equal(t, "New", res.Title)
+
+	require.Nil(t, c.DeleteForm("abc"))
+	require.Len(t, sc.Reqs, 2)
+	assert.Equal(t, "PUT", sc.Reqs[0].Method)
+	assert.Equal(t, "/v0.4/forms/abc", sc.Reqs[0].URL.Path)
+	assert.Equal(t, "DELETE", sc.Reqs[1].Method)
+	assert.Equal(t, "/v0.4/forms/abc", sc.Reqs[1].URL.Path)
+}
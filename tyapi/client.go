@@ -0,0 +1,225 @@
+package tyapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/levenlabs/go-llog"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// DefaultBaseURL is the base url for the typeform api
+const DefaultBaseURL = "https://api.typeform.io"
+
+// DefaultVersion is the version of the typeform api that is used by default
+const DefaultVersion = "v0.4"
+
+// DefaultUserAgent is sent as the User-Agent header unless overridden
+const DefaultUserAgent = "go-typeform"
+
+// HTTPClient is an interface that describes http.Client so we can override
+// what client we use in testing
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Logger is used by the Client to log what it's doing. Its methods match the
+// functions exported by llog, which is what's used by default
+type Logger interface {
+	Debug(msg string, kv ...llog.KV)
+	Warn(msg string, kv ...llog.KV)
+}
+
+// llogLogger implements Logger by calling the llog package
+type llogLogger struct{}
+
+func (llogLogger) Debug(msg string, kv ...llog.KV) {
+	llog.Debug(msg, kv...)
+}
+
+func (llogLogger) Warn(msg string, kv ...llog.KV) {
+	llog.Warn(msg, kv...)
+}
+
+// Client makes requests to the typeform api using a single APIToken. It is
+// safe to use a Client from multiple goroutines.
+type Client struct {
+	token      string
+	baseURL    string
+	version    string
+	userAgent  string
+	httpClient HTTPClient
+	logger     Logger
+	retry      RetryPolicy
+	limiter    *RateLimiter
+}
+
+// Option is passed to NewClient to configure the Client
+type Option func(*Client)
+
+// WithToken sets the APIToken used to authenticate requests
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithBaseURL overrides DefaultBaseURL. The url should not contain a trailing
+// slash or the api version.
+func WithBaseURL(u string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(u, "/")
+	}
+}
+
+// WithVersion overrides DefaultVersion
+func WithVersion(v string) Option {
+	return func(c *Client) {
+		c.version = v
+	}
+}
+
+// WithHTTPClient overrides http.DefaultClient as the client used to make
+// requests
+func WithHTTPClient(hc HTTPClient) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithUserAgent overrides DefaultUserAgent
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+// WithLogger overrides the default Logger, which uses llog
+func WithLogger(l Logger) Option {
+	return func(c *Client) {
+		c.logger = l
+	}
+}
+
+// NewClient returns a Client configured with the given Options
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		baseURL:    DefaultBaseURL,
+		version:    DefaultVersion,
+		userAgent:  DefaultUserAgent,
+		httpClient: http.DefaultClient,
+		logger:     llogLogger{},
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// url returns the full url for the given path, which should start with a slash
+func (c *Client) url(path string) string {
+	return fmt.Sprintf("%s/%s%s", c.baseURL, c.version, path)
+}
+
+// do sends a request with the json encoding of body, if its non-nil, and
+// decodes the response into dst, if its non-nil. If the response code doesn't
+// match expCode then an error is returned. The request is canceled if ctx is
+// canceled before the response is read. Failed requests are retried according
+// to the Client's RetryPolicy.
+func (c *Client) do(ctx context.Context, method, path string, body interface{}, expCode int, dst interface{}) error {
+	if c.token == "" {
+		return errEmptyToken
+	}
+
+	var b []byte
+	if body != nil {
+		var err error
+		if b, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	kv := llog.KV{
+		"method": method,
+		"path":   path,
+	}
+	var resp *http.Response
+	var err error
+	for attempt := 1; ; attempt++ {
+		kv["attempt"] = attempt
+		if c.limiter != nil {
+			if err := c.limiter.wait(ctx, method, path); err != nil {
+				return err
+			}
+		}
+		c.logger.Debug("sending typeform request", kv)
+		resp, err = c.send(ctx, method, path, b)
+		wait, retry := c.retry.shouldRetry(ctx, method, attempt, resp, err)
+		if !retry {
+			break
+		}
+
+		ra := RetryAttempt{
+			Method:  method,
+			Path:    path,
+			Attempt: attempt,
+			Err:     err,
+			Wait:    wait,
+		}
+		if resp != nil {
+			ra.StatusCode = resp.StatusCode
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		kv["status"] = ra.StatusCode
+		kv["wait"] = wait.String()
+		c.logger.Warn("retrying typeform request", kv)
+		delete(kv, "status")
+		delete(kv, "wait")
+		if c.retry.OnRetry != nil {
+			c.retry.OnRetry(ra)
+		}
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+	if err != nil {
+		kv["error"] = err
+		c.logger.Warn("error sending typeform request", kv)
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != expCode {
+		kv["status"] = resp.StatusCode
+		c.logger.Warn("unexpected response from typeform", kv)
+		return newError(path, resp)
+	}
+
+	if dst == nil {
+		return nil
+	}
+	dec := json.NewDecoder(resp.Body)
+	return dec.Decode(dst)
+}
+
+// send makes a single request with b as the body, if its non-nil
+func (c *Client) send(ctx context.Context, method, path string, b []byte) (*http.Response, error) {
+	var r io.Reader
+	if b != nil {
+		r = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.url(path), r)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-API-TOKEN", c.token)
+	req.Header.Set("User-Agent", c.userAgent)
+	if b != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.httpClient.Do(req)
+}
@@ -0,0 +1,115 @@
+package tyapi
+
+import (
+	"context"
+	"github.com/levenlabs/go-typeform/tyform"
+	"net/http"
+	"net/url"
+)
+
+// DesignResult is a design returned from typeform along with its ID
+type DesignResult struct {
+	ID            string `json:"id"                bson:"_id"`
+	tyform.Design `bson:",inline"`
+}
+
+// DesignList is a single page of designs returned by ListDesigns
+type DesignList struct {
+	TotalItems int            `json:"total_items"`
+	PageCount  int            `json:"page_count"`
+	Items      []DesignResult `json:"items"`
+}
+
+func designPath(id string) string {
+	return "/designs/" + url.PathEscape(id)
+}
+
+// CreateDesign creates a design on typeform. The returned ID can be used as a
+// form's DesignID.
+func (c *Client) CreateDesign(d *tyform.Design) (*DesignResult, error) {
+	return c.CreateDesignContext(context.Background(), d)
+}
+
+// CreateDesignContext is like CreateDesign but the request is canceled along
+// with ctx
+func (c *Client) CreateDesignContext(ctx context.Context, d *tyform.Design) (*DesignResult, error) {
+	res := &DesignResult{}
+	if err := c.do(ctx, "POST", "/designs", d, http.StatusCreated, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetDesign fetches the design with the given ID
+func (c *Client) GetDesign(id string) (*DesignResult, error) {
+	return c.GetDesignContext(context.Background(), id)
+}
+
+// GetDesignContext is like GetDesign but the request is canceled along with
+// ctx
+func (c *Client) GetDesignContext(ctx context.Context, id string) (*DesignResult, error) {
+	res := &DesignResult{}
+	if err := c.do(ctx, "GET", designPath(id), nil, http.StatusOK, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListDesigns fetches a single page of designs
+func (c *Client) ListDesigns(o ListOptions) (*DesignList, error) {
+	return c.ListDesignsContext(context.Background(), o)
+}
+
+// ListDesignsContext is like ListDesigns but the request is canceled along
+// with ctx
+func (c *Client) ListDesignsContext(ctx context.Context, o ListOptions) (*DesignList, error) {
+	res := &DesignList{}
+	if err := c.do(ctx, "GET", withQuery("/designs", o.values()), nil, http.StatusOK, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DesignIterator iterates over all of the designs returned by ListDesigns
+type DesignIterator struct {
+	*Iterator
+}
+
+// Design returns the current design. It's only valid after Next returns true.
+func (it DesignIterator) Design() *DesignResult {
+	d, _ := it.Value().(*DesignResult)
+	return d
+}
+
+// IterateDesigns returns a DesignIterator that fetches every page of designs
+// starting at the one described by o
+func (c *Client) IterateDesigns(ctx context.Context, o ListOptions) DesignIterator {
+	return DesignIterator{NewIterator(ctx, o, func(ctx context.Context, o ListOptions) ([]interface{}, *ListOptions, error) {
+		res, err := c.ListDesignsContext(ctx, o)
+		if err != nil {
+			return nil, nil, err
+		}
+		items := make([]interface{}, len(res.Items))
+		for i := range res.Items {
+			items[i] = &res.Items[i]
+		}
+		return items, nextPage(o, res.PageCount), nil
+	})}
+}
+
+// CreateWithDesign creates the design and then creates the form using the new
+// design's ID as its DesignID
+func (c *Client) CreateWithDesign(f *tyform.Form, d *tyform.Design) (*CreateResult, error) {
+	return c.CreateWithDesignContext(context.Background(), f, d)
+}
+
+// CreateWithDesignContext is like CreateWithDesign but the requests are
+// canceled along with ctx
+func (c *Client) CreateWithDesignContext(ctx context.Context, f *tyform.Form, d *tyform.Design) (*CreateResult, error) {
+	dr, err := c.CreateDesignContext(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	f.DesignID = dr.ID
+	return c.CreateContext(ctx, f)
+}
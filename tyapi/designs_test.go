@@ -0,0 +1,80 @@
+package tyapi
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/levenlabs/go-typeform/tyform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"net/http"
+	. "testing"
+)
+
+func TestCreateGetDesign(t *T) {
+	sc := &seqClient{
+		Responses: []seqResponse{
+			{StatusCode: http.StatusCreated, Body: `{"id":"d1","colors":{"question":"#3D3D3D"},"font":"Vollkorn"}`},
+			{StatusCode: http.StatusOK, Body: `{"id":"d1","colors":{"question":"#3D3D3D"},"font":"Vollkorn"}`},
+		},
+	}
+	c := NewClient(WithToken("test"), WithHTTPClient(sc))
+	d := &tyform.Design{
+		Colors: tyform.DesignColors{Question: "#3D3D3D"},
+		Font:   "Vollkorn",
+	}
+	res, err := c.CreateDesign(d)
+	require.Nil(t, err)
+	assert.Equal(t, "d1", res.ID)
+	assert.Equal(t, *d, res.Design)
+
+	res, err = c.GetDesign("d1")
+	require.Nil(t, err)
+	assert.Equal(t, "d1", res.ID)
+	assert.Equal(t, *d, res.Design)
+
+	require.Len(t, sc.Reqs, 2)
+	assert.Equal(t, "POST", sc.Reqs[0].Method)
+	assert.Equal(t, "/v0.4/designs", sc.Reqs[0].URL.Path)
+	assert.Equal(t, "GET", sc.Reqs[1].Method)
+	assert.Equal(t, "/v0.4/designs/d1", sc.Reqs[1].URL.Path)
+}
+
+func TestIterateDesigns(t *T) {
+	sc := &seqClient{
+		Responses: []seqResponse{
+			{StatusCode: http.StatusOK, Body: `{"page_count":2,"items":[{"id":"a"}]}`},
+			{StatusCode: http.StatusOK, Body: `{"page_count":2,"items":[{"id":"b"}]}`},
+		},
+	}
+	c := NewClient(WithToken("test"), WithHTTPClient(sc))
+	it := c.IterateDesigns(context.Background(), ListOptions{})
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Design().ID)
+	}
+	require.Nil(t, it.Err())
+	assert.Equal(t, []string{"a", "b"}, ids)
+}
+
+func TestCreateWithDesign(t *T) {
+	sc := &seqClient{
+		Responses: []seqResponse{
+			{StatusCode: http.StatusCreated, Body: `{"id":"d1"}`},
+			{StatusCode: http.StatusCreated, Body: `{"id":"f1"}`},
+		},
+	}
+	c := NewClient(WithToken("test"), WithHTTPClient(sc))
+	f := &tyform.Form{}
+	res, err := c.CreateWithDesign(f, &tyform.Design{})
+	require.Nil(t, err)
+	assert.Equal(t, "f1", res.ID)
+	assert.Equal(t, "d1", f.DesignID)
+
+	require.Len(t, sc.Reqs, 2)
+	b, err := ioutil.ReadAll(sc.Reqs[1].Body)
+	require.Nil(t, err)
+	sent := &tyform.Form{}
+	require.Nil(t, json.Unmarshal(b, sent))
+	assert.Equal(t, "d1", sent.DesignID)
+}
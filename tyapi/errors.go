@@ -0,0 +1,123 @@
+package tyapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// These errors can be used with errors.Is to check what kind of Error was
+// returned from a request
+var (
+	// ErrUnauthorized is matched by a 401 or 403 response
+	ErrUnauthorized = errors.New("typeform: unauthorized")
+
+	// ErrValidation is matched by a 400 or 422 response
+	ErrValidation = errors.New("typeform: validation failed")
+
+	// ErrNotFound is matched by a 404 response
+	ErrNotFound = errors.New("typeform: not found")
+
+	// ErrTooManyRequests is matched by a 429 response
+	ErrTooManyRequests = errors.New("typeform: too many requests")
+
+	// ErrServer is matched by a 5xx response
+	ErrServer = errors.New("typeform: server error")
+)
+
+// maxErrorBody is the most of an error response's body that is read
+const maxErrorBody = 64 * 1024
+
+// FieldError describes a problem with a single field in a request
+type FieldError struct {
+	Code        string `json:"code,omitempty"`
+	Field       string `json:"field"`
+	Description string `json:"description"`
+	In          string `json:"in,omitempty"`
+}
+
+// Error is returned when typeform responds with an unexpected status code. If
+// the response had a json body then ErrorType, Field, Description and Details
+// are filled in from it. Use errors.Is with ErrUnauthorized, ErrValidation,
+// etc to check what kind of error it is.
+type Error struct {
+	ErrorType   string       `json:"error"`
+	Field       string       `json:"field"`
+	Description string       `json:"description"`
+	Details     []FieldError `json:"details,omitempty"`
+
+	StatusCode int    `json:"-"`
+	Status     string `json:"-"`
+	RequestID  string `json:"-"`
+	Path       string `json:"-"`
+	Body       []byte `json:"-"`
+}
+
+// newError reads the response's body and returns an Error describing it
+func newError(path string, resp *http.Response) Error {
+	e := Error{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Path:       path,
+	}
+	if resp.Header != nil {
+		e.RequestID = resp.Header.Get("X-Request-Id")
+	}
+	b, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if err != nil {
+		return e
+	}
+	e.Body = b
+	if json.Unmarshal(b, &e) != nil {
+		// make sure a partial decode doesn't leave anything behind
+		e.ErrorType, e.Field, e.Description, e.Details = "", "", "", nil
+	}
+	return e
+}
+
+func (e Error) Error() string {
+	if e.ErrorType == "" && e.Description == "" {
+		return fmt.Sprintf("unexpected response from %s: %s", e.Path, e.Status)
+	}
+	return fmt.Sprintf("%s on field %s: %s", e.ErrorType, e.Field, e.Description)
+}
+
+// Is implements the interface used by errors.Is to match the Error against
+// ErrUnauthorized, ErrValidation, etc
+func (e Error) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrTooManyRequests:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= 500 && e.StatusCode < 600
+	}
+	return false
+}
+
+// FieldErrors returns all of the field-level errors in the Error, including
+// the top-level Field if it was set
+func (e Error) FieldErrors() []FieldError {
+	fe := make([]FieldError, 0, len(e.Details)+1)
+	if e.Field != "" {
+		fe = append(fe, FieldError{
+			Code:        e.ErrorType,
+			Field:       e.Field,
+			Description: e.Description,
+		})
+	}
+	for _, d := range e.Details {
+		if d.Field != "" {
+			fe = append(fe, d)
+		}
+	}
+	return fe
+}
@@ -0,0 +1,85 @@
+package tyapi
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"net/http"
+	. "testing"
+)
+
+func testResponse(code int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: code,
+		Status:     http.StatusText(code),
+		Header:     http.Header{"X-Request-Id": []string{"req1"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+func TestNewError(t *T) {
+	e := newError("/forms", testResponse(http.StatusBadRequest, `{
+		"error": "invalid",
+		"field": "title",
+		"description": "is required",
+		"details": [
+			{"code": "required", "field": "fields", "description": "is required", "in": "body"}
+		]
+	}`))
+	assert.Equal(t, "invalid", e.ErrorType)
+	assert.Equal(t, "title", e.Field)
+	assert.Equal(t, "is required", e.Description)
+	assert.Equal(t, http.StatusBadRequest, e.StatusCode)
+	assert.Equal(t, "req1", e.RequestID)
+	assert.Equal(t, "/forms", e.Path)
+	assert.NotEmpty(t, e.Body)
+	assert.Equal(t, "invalid on field title: is required", e.Error())
+	assert.Equal(t, []FieldError{
+		{Code: "invalid", Field: "title", Description: "is required"},
+		{Code: "required", Field: "fields", Description: "is required", In: "body"},
+	}, e.FieldErrors())
+
+	e = newError("/forms", testResponse(http.StatusBadGateway, `<html></html>`))
+	assert.Equal(t, "", e.ErrorType)
+	assert.Equal(t, `<html></html>`, string(e.Body))
+	assert.Equal(t, "unexpected response from /forms: Bad Gateway", e.Error())
+	assert.Len(t, e.FieldErrors(), 0)
+}
+
+func TestErrorIs(t *T) {
+	codes := map[error][]int{
+		ErrUnauthorized:    {http.StatusUnauthorized, http.StatusForbidden},
+		ErrValidation:      {http.StatusBadRequest, http.StatusUnprocessableEntity},
+		ErrNotFound:        {http.StatusNotFound},
+		ErrTooManyRequests: {http.StatusTooManyRequests},
+		ErrServer:          {http.StatusInternalServerError, http.StatusServiceUnavailable},
+	}
+	for target, cc := range codes {
+		for _, c := range cc {
+			var err error = Error{StatusCode: c}
+			for other := range codes {
+				assert.Equal(t, other == target, errors.Is(err, other), "%d %v", c, other)
+			}
+		}
+	}
+}
+
+func TestClientError(t *T) {
+	sc := &seqClient{
+		Responses: []seqResponse{
+			{StatusCode: http.StatusNotFound, Body: `not found`},
+		},
+	}
+	c := NewClient(WithToken("test"), WithHTTPClient(sc))
+	_, err := c.GetForm("abc")
+	require.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	var e Error
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, http.StatusNotFound, e.StatusCode)
+	assert.Equal(t, "not found", string(e.Body))
+	assert.Equal(t, "/forms/abc", e.Path)
+}
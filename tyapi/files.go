@@ -0,0 +1,97 @@
+package tyapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// ErrFileTooLarge is returned by DownloadFile when the file is larger than the
+// given limit
+var ErrFileTooLarge = errors.New("file is larger than the limit")
+
+// DownloadFile downloads the file in the answer using the Client's http client
+// and writes it to w. If maxSize is greater than 0 and the file is larger than
+// maxSize then ErrFileTooLarge is returned, although up to maxSize bytes might
+// have already been written to w. The number of bytes written to w is
+// returned.
+//
+// The Client's token is only sent if the file is hosted on the same host as
+// the api, and it's removed if the download redirects to another host. If the
+// Client was given an HTTPClient that isn't an *http.Client then it's up to
+// that HTTPClient to not forward the X-API-TOKEN header on redirects.
+func (c *Client) DownloadFile(ctx context.Context, f *FileValue, w io.Writer, maxSize int64) (int64, error) {
+	if maxSize > 0 && f.Size > maxSize {
+		return 0, ErrFileTooLarge
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", f.URL, nil)
+	if err != nil {
+		return 0, err
+	}
+	hc := c.httpClient
+	if bu, err := url.Parse(c.baseURL); err == nil && bu.Host == req.URL.Host {
+		req.Header.Set("X-API-TOKEN", c.token)
+		hc = stripTokenOnRedirect(hc, bu.Host)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, newError(req.URL.Path, resp)
+	}
+	if maxSize > 0 && resp.ContentLength > maxSize {
+		return 0, ErrFileTooLarge
+	}
+
+	if maxSize <= 0 {
+		return io.Copy(w, resp.Body)
+	}
+	n, err := io.Copy(w, io.LimitReader(resp.Body, maxSize))
+	if err != nil || n < maxSize {
+		return n, err
+	}
+	// check for one more byte, without writing it, to know if it was too large.
+	// CopyN is used since a single Read can return nothing without an error.
+	extra, err := io.CopyN(ioutil.Discard, resp.Body, 1)
+	if extra > 0 {
+		return n, ErrFileTooLarge
+	}
+	if err != nil && err != io.EOF {
+		return n, err
+	}
+	return n, nil
+}
+
+// stripTokenOnRedirect returns a copy of hc, if it's an *http.Client, that
+// removes the X-API-TOKEN header when redirected away from host. net/http only
+// does that for standard headers like Authorization.
+func stripTokenOnRedirect(hc HTTPClient, host string) HTTPClient {
+	c, ok := hc.(*http.Client)
+	if !ok {
+		return hc
+	}
+	cc := *c
+	check := c.CheckRedirect
+	cc.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if req.URL.Host != host {
+			req.Header.Del("X-API-TOKEN")
+		}
+		if check != nil {
+			return check(req, via)
+		}
+		// the same limit as http.Client's default
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	return &cc
+}
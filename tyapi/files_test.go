@@ -0,0 +1,166 @@
+package tyapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	. "testing"
+)
+
+func TestDownloadFile(t *T) {
+	sc := &seqClient{
+		Responses: []seqResponse{
+			{StatusCode: http.StatusOK, Body: "hello"},
+			{StatusCode: http.StatusOK, Body: "hello"},
+			{StatusCode: http.StatusNotFound},
+		},
+	}
+	c := NewClient(WithToken("test"), WithHTTPClient(sc))
+	ctx := context.Background()
+	f := &FileValue{
+		URL:  "https://files.example.com/a.pdf",
+		Size: 5,
+	}
+
+	buf := &bytes.Buffer{}
+	n, err := c.DownloadFile(ctx, f, buf, 5)
+	require.Nil(t, err)
+	assert.EqualValues(t, 5, n)
+	assert.Equal(t, "hello", buf.String())
+	assert.Equal(t, "", sc.Reqs[0].Header.Get("X-API-TOKEN"))
+
+	// the reported size can be wrong so the actual body is limited too
+	f.Size = 0
+	buf.Reset()
+	n, err = c.DownloadFile(ctx, f, buf, 4)
+	assert.Equal(t, ErrFileTooLarge, err)
+	assert.EqualValues(t, 4, n)
+
+	// the reported size is checked before downloading
+	f.Size = 5
+	_, err = c.DownloadFile(ctx, f, buf, 4)
+	assert.Equal(t, ErrFileTooLarge, err)
+	assert.Len(t, sc.Reqs, 2)
+
+	f.URL = DefaultBaseURL + "/files/a.pdf"
+	_, err = c.DownloadFile(ctx, f, buf, 0)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	require.Len(t, sc.Reqs, 3)
+	assert.Equal(t, "test", sc.Reqs[2].Header.Get("X-API-TOKEN"))
+}
+
+func TestDownloadFileUnknownLength(t *T) {
+	sc := &seqClient{
+		Responses: []seqResponse{
+			{StatusCode: http.StatusOK, Body: "0123456789", ContentLength: -1},
+			{StatusCode: http.StatusOK, Body: "01234", ContentLength: -1},
+		},
+	}
+	c := NewClient(WithToken("test"), WithHTTPClient(sc))
+	ctx := context.Background()
+	f := &FileValue{URL: "https://files.example.com/a.pdf"}
+
+	// no more than maxSize bytes are written
+	buf := &bytes.Buffer{}
+	n, err := c.DownloadFile(ctx, f, buf, 5)
+	assert.Equal(t, ErrFileTooLarge, err)
+	assert.EqualValues(t, 5, n)
+	assert.Equal(t, "01234", buf.String())
+
+	// exactly maxSize bytes is fine
+	buf.Reset()
+	n, err = c.DownloadFile(ctx, f, buf, 5)
+	require.Nil(t, err)
+	assert.EqualValues(t, 5, n)
+	assert.Equal(t, "01234", buf.String())
+}
+
+// emptyReadBody returns the first N bytes of its data, then a read with no
+// data and no error, then the rest of its data
+type emptyReadBody struct {
+	data  []byte
+	N     int
+	empty bool
+}
+
+func (b *emptyReadBody) Read(p []byte) (int, error) {
+	if b.N == 0 && !b.empty {
+		b.empty = true
+		return 0, nil
+	}
+	if len(b.data) == 0 {
+		return 0, io.EOF
+	}
+	l := len(b.data)
+	if b.N > 0 && b.N < l {
+		l = b.N
+	}
+	n := copy(p, b.data[:l])
+	b.data = b.data[n:]
+	if b.N > 0 {
+		b.N -= n
+	}
+	return n, nil
+}
+
+func (b *emptyReadBody) Close() error {
+	return nil
+}
+
+type bodyClient struct {
+	Body io.ReadCloser
+}
+
+func (c *bodyClient) Do(r *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode:    http.StatusOK,
+		Header:        http.Header{},
+		ContentLength: -1,
+		Body:          c.Body,
+	}, nil
+}
+
+func TestDownloadFileEmptyRead(t *T) {
+	hc := &bodyClient{Body: &emptyReadBody{data: []byte("0123456789"), N: 5}}
+	c := NewClient(WithToken("test"), WithHTTPClient(hc))
+	buf := &bytes.Buffer{}
+	n, err := c.DownloadFile(context.Background(), &FileValue{URL: "https://files.example.com/a.pdf"}, buf, 5)
+	assert.Equal(t, ErrFileTooLarge, err)
+	assert.EqualValues(t, 5, n)
+	assert.Equal(t, "01234", buf.String())
+}
+
+func TestDownloadFileRedirect(t *T) {
+	var cdnToken, apiToken string
+	cdn := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cdnToken = r.Header.Get("X-API-TOKEN")
+		w.Write([]byte("hello"))
+	}))
+	defer cdn.Close()
+	// use localhost so the cdn's host is different than the api's
+	cdnURL := strings.Replace(cdn.URL, "127.0.0.1", "localhost", 1)
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiToken = r.Header.Get("X-API-TOKEN")
+		http.Redirect(w, r, cdnURL+"/a.pdf", http.StatusFound)
+	}))
+	defer api.Close()
+
+	hc := &http.Client{}
+	c := NewClient(WithToken("secret"), WithBaseURL(api.URL), WithHTTPClient(hc))
+	buf := &bytes.Buffer{}
+	n, err := c.DownloadFile(context.Background(), &FileValue{URL: api.URL + "/files/a.pdf"}, buf, 0)
+	require.Nil(t, err)
+	assert.EqualValues(t, 5, n)
+	assert.Equal(t, "hello", buf.String())
+	assert.Equal(t, "secret", apiToken)
+	assert.Equal(t, "", cdnToken)
+	// the Client's http client isn't changed
+	assert.Nil(t, hc.CheckRedirect)
+}
@@ -0,0 +1,57 @@
+package tyapi
+
+import (
+	"context"
+	"github.com/levenlabs/go-typeform/tyform"
+	"net/http"
+	"net/url"
+)
+
+// ImageResult is an image that has been registered with typeform
+type ImageResult struct {
+	ID          string `json:"id"`
+	OriginalURL string `json:"original_url"`
+}
+
+// Attachment returns a tyform.Attachment that references the image and can be
+// set on a field
+func (i *ImageResult) Attachment() *tyform.Attachment {
+	return &tyform.Attachment{
+		Type:    tyform.AttachmentImage,
+		ImageID: i.ID,
+	}
+}
+
+type imageRequest struct {
+	URL string `json:"url"`
+}
+
+// CreateImage registers the image at the given url with typeform. The returned
+// ID can be used to reference the image in fields.
+func (c *Client) CreateImage(u string) (*ImageResult, error) {
+	return c.CreateImageContext(context.Background(), u)
+}
+
+// CreateImageContext is like CreateImage but the request is canceled along
+// with ctx
+func (c *Client) CreateImageContext(ctx context.Context, u string) (*ImageResult, error) {
+	res := &ImageResult{}
+	if err := c.do(ctx, "POST", "/images", imageRequest{u}, http.StatusCreated, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetImage fetches the image with the given ID
+func (c *Client) GetImage(id string) (*ImageResult, error) {
+	return c.GetImageContext(context.Background(), id)
+}
+
+// GetImageContext is like GetImage but the request is canceled along with ctx
+func (c *Client) GetImageContext(ctx context.Context, id string) (*ImageResult, error) {
+	res := &ImageResult{}
+	if err := c.do(ctx, "GET", "/images/"+url.PathEscape(id), nil, http.StatusOK, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
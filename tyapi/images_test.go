@@ -0,0 +1,37 @@
+package tyapi
+
+import (
+	"github.com/levenlabs/go-typeform/tyform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"net/http"
+	. "testing"
+)
+
+func TestCreateImage(t *T) {
+	sc := &seqClient{
+		Responses: []seqResponse{
+			{StatusCode: http.StatusCreated, Body: `{"id":"img","original_url":"http://example.com/a.png"}`},
+			{StatusCode: http.StatusOK, Body: `{"id":"img","original_url":"http://example.com/a.png"}`},
+		},
+	}
+	c := NewClient(WithToken("test"), WithHTTPClient(sc))
+	res, err := c.CreateImage("http://example.com/a.png")
+	require.Nil(t, err)
+	assert.Equal(t, "img", res.ID)
+	assert.Equal(t, "http://example.com/a.png", res.OriginalURL)
+	assert.Equal(t, &tyform.Attachment{Type: tyform.AttachmentImage, ImageID: "img"}, res.Attachment())
+
+	res, err = c.GetImage("img")
+	require.Nil(t, err)
+	assert.Equal(t, "img", res.ID)
+
+	require.Len(t, sc.Reqs, 2)
+	assert.Equal(t, "POST", sc.Reqs[0].Method)
+	assert.Equal(t, "/v0.4/images", sc.Reqs[0].URL.Path)
+	b, err := ioutil.ReadAll(sc.Reqs[0].Body)
+	require.Nil(t, err)
+	assert.Equal(t, `{"url":"http://example.com/a.png"}`, string(b))
+	assert.Equal(t, "/v0.4/images/img", sc.Reqs[1].URL.Path)
+}
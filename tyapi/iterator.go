@@ -0,0 +1,122 @@
+package tyapi
+
+import (
+	"context"
+)
+
+// PageFunc fetches a single page of a list endpoint using the given
+// ListOptions. It returns the items on the page and the options to fetch the
+// next page with, or nil if this was the last page.
+type PageFunc func(ctx context.Context, o ListOptions) ([]interface{}, *ListOptions, error)
+
+// Iterator lazily fetches pages from a list endpoint and iterates over each of
+// the items on them. It's used like bufio.Scanner:
+//
+//	for it.Next() {
+//		v := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// An Iterator is not safe to use from multiple goroutines.
+type Iterator struct {
+	ctx   context.Context
+	fn    PageFunc
+	next  *ListOptions
+	items []interface{}
+	value interface{}
+	err   error
+}
+
+// NewIterator returns an Iterator that starts at the page described by o and
+// calls fn to fetch each page. ctx is passed to fn and once it's canceled the
+// iterator stops with the context's error.
+func NewIterator(ctx context.Context, o ListOptions, fn PageFunc) *Iterator {
+	return &Iterator{
+		ctx:  ctx,
+		fn:   fn,
+		next: &o,
+	}
+}
+
+// Next advances to the next item, fetching the next page if needed. It returns
+// false when there are no items left or an error was encountered.
+func (it *Iterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	for len(it.items) == 0 {
+		if it.next == nil {
+			it.value = nil
+			return false
+		}
+		if it.err = it.ctx.Err(); it.err != nil {
+			it.value = nil
+			return false
+		}
+		it.items, it.next, it.err = it.fn(it.ctx, *it.next)
+		if it.err != nil {
+			it.value = nil
+			return false
+		}
+	}
+	it.value = it.items[0]
+	it.items = it.items[1:]
+	return true
+}
+
+// Value returns the current item. It's only valid after Next returns true.
+func (it *Iterator) Value() interface{} {
+	return it.value
+}
+
+// Err returns the error, if any, that stopped the iteration
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// Each calls fn with every remaining item. If fn returns an error the
+// iteration stops and that error is returned, otherwise Err is returned.
+func (it *Iterator) Each(fn func(interface{}) error) error {
+	for it.Next() {
+		if err := fn(it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// Chan returns a channel that receives every remaining item and is closed once
+// there are none left or an error is encountered, which can be retrieved with
+// Err after the channel is closed. The items are fetched in a separate
+// goroutine which only stops early if the Iterator's context is canceled, so
+// either read until the channel is closed or cancel the context.
+func (it *Iterator) Chan() <-chan interface{} {
+	ch := make(chan interface{})
+	go func() {
+		defer close(ch)
+		for it.Next() {
+			select {
+			case ch <- it.Value():
+			case <-it.ctx.Done():
+				it.err = it.ctx.Err()
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// nextPage returns the options for the page after o if there are more than
+// pageCount pages
+func nextPage(o ListOptions, pageCount int) *ListOptions {
+	if o.Page < 1 {
+		o.Page = 1
+	}
+	if o.Page >= pageCount {
+		return nil
+	}
+	o.Page++
+	return &o
+}
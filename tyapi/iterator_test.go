@@ -0,0 +1,95 @@
+package tyapi
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	. "testing"
+)
+
+// intPages returns a PageFunc that returns the given pages of ints
+func intPages(pages ...[]int) PageFunc {
+	return func(_ context.Context, o ListOptions) ([]interface{}, *ListOptions, error) {
+		if o.Page < 1 {
+			o.Page = 1
+		}
+		p := pages[o.Page-1]
+		items := make([]interface{}, len(p))
+		for i := range p {
+			items[i] = p[i]
+		}
+		return items, nextPage(o, len(pages)), nil
+	}
+}
+
+func TestIterator(t *T) {
+	it := NewIterator(context.Background(), ListOptions{}, intPages([]int{1, 2}, []int{}, []int{3}))
+	var got []int
+	for it.Next() {
+		got = append(got, it.Value().(int))
+	}
+	require.Nil(t, it.Err())
+	assert.Equal(t, []int{1, 2, 3}, got)
+	assert.False(t, it.Next())
+
+	it = NewIterator(context.Background(), ListOptions{Page: 2}, intPages([]int{1, 2}, []int{3}))
+	got = nil
+	require.Nil(t, it.Each(func(v interface{}) error {
+		got = append(got, v.(int))
+		return nil
+	}))
+	assert.Equal(t, []int{3}, got)
+
+	errTest := errors.New("test")
+	it = NewIterator(context.Background(), ListOptions{}, intPages([]int{1, 2}))
+	assert.Equal(t, errTest, it.Each(func(interface{}) error {
+		return errTest
+	}))
+
+	it = NewIterator(context.Background(), ListOptions{}, func(context.Context, ListOptions) ([]interface{}, *ListOptions, error) {
+		return nil, nil, errTest
+	})
+	assert.False(t, it.Next())
+	assert.Equal(t, errTest, it.Err())
+}
+
+func TestIteratorChan(t *T) {
+	it := NewIterator(context.Background(), ListOptions{}, intPages([]int{1, 2}, []int{3}))
+	var got []int
+	for v := range it.Chan() {
+		got = append(got, v.(int))
+	}
+	require.Nil(t, it.Err())
+	assert.Equal(t, []int{1, 2, 3}, got)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it = NewIterator(ctx, ListOptions{}, intPages([]int{1, 2}, []int{3}))
+	ch := it.Chan()
+	assert.Equal(t, 1, <-ch)
+	cancel()
+	for range ch {
+	}
+	assert.Equal(t, context.Canceled, it.Err())
+}
+
+func TestIterateForms(t *T) {
+	sc := &seqClient{
+		Responses: []seqResponse{
+			{StatusCode: http.StatusOK, Body: `{"page_count":2,"items":[{"id":"a"},{"id":"b"}]}`},
+			{StatusCode: http.StatusOK, Body: `{"page_count":2,"items":[{"id":"c"}]}`},
+		},
+	}
+	c := NewClient(WithToken("test"), WithHTTPClient(sc))
+	it := c.IterateForms(context.Background(), ListOptions{PageSize: 2})
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Form().ID)
+	}
+	require.Nil(t, it.Err())
+	assert.Equal(t, []string{"a", "b", "c"}, ids)
+	require.Len(t, sc.Reqs, 2)
+	assert.Equal(t, "page_size=2", sc.Reqs[0].URL.RawQuery)
+	assert.Equal(t, "page=2&page_size=2", sc.Reqs[1].URL.RawQuery)
+}
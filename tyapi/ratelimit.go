@@ -0,0 +1,197 @@
+package tyapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned when a RateLimiter in RateLimitFailFast mode has
+// no tokens available for a request
+var ErrRateLimited = errors.New("client-side rate limit exceeded")
+
+// RateLimitMode describes what a RateLimiter does when it has no tokens left
+type RateLimitMode int
+
+const (
+	// RateLimitBlock waits until a token is available or the request's
+	// context is canceled
+	RateLimitBlock RateLimitMode = iota
+
+	// RateLimitFailFast returns ErrRateLimited immediately
+	RateLimitFailFast
+)
+
+// RateLimit describes a token bucket. Rate is the number of requests per
+// second that are allowed and Burst is the number of requests that can be made
+// at once. A Rate of 0 or less means there is no limit.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimiterStats contains metrics about a RateLimiter
+type RateLimiterStats struct {
+	// Waits is the number of requests that had to wait for a token
+	Waits int64
+
+	// WaitTime is the total time that requests spent waiting for tokens
+	WaitTime time.Duration
+
+	// Rejected is the number of requests that failed with ErrRateLimited
+	Rejected int64
+}
+
+// bucket is a token bucket that refills continuously
+type bucket struct {
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(l RateLimit, now time.Time) *bucket {
+	if l.Burst < 1 {
+		l.Burst = 1
+	}
+	return &bucket{
+		limit:  l,
+		tokens: float64(l.Burst),
+		last:   now,
+	}
+}
+
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.limit.Rate
+		if max := float64(b.limit.Burst); b.tokens > max {
+			b.tokens = max
+		}
+	}
+	b.last = now
+}
+
+// RateLimiter limits how often a Client makes requests. Limits can be set per
+// endpoint and a single RateLimiter can be shared by many Clients and
+// goroutines.
+type RateLimiter struct {
+	mode      RateLimitMode
+	def       RateLimit
+	endpoints map[string]RateLimit
+	now       func() time.Time
+
+	l       sync.Mutex
+	buckets map[string]*bucket
+	stats   RateLimiterStats
+}
+
+// NewRateLimiter returns a RateLimiter that applies def to all endpoints that
+// don't have their own limit set with SetEndpointLimit
+func NewRateLimiter(def RateLimit, mode RateLimitMode) *RateLimiter {
+	return &RateLimiter{
+		mode:      mode,
+		def:       def,
+		endpoints: map[string]RateLimit{},
+		now:       time.Now,
+		buckets:   map[string]*bucket{},
+	}
+}
+
+// SetEndpointLimit sets the limit for an endpoint, which is the first segment
+// of the path optionally preceded by a method, like "/forms" or "POST /forms".
+// A limit with a method takes precedence over one without. Each endpoint limit
+// has its own bucket that's separate from the default bucket.
+func (r *RateLimiter) SetEndpointLimit(endpoint string, l RateLimit) {
+	r.l.Lock()
+	defer r.l.Unlock()
+	r.endpoints[endpoint] = l
+	delete(r.buckets, endpoint)
+}
+
+// Stats returns the current metrics of the RateLimiter
+func (r *RateLimiter) Stats() RateLimiterStats {
+	r.l.Lock()
+	defer r.l.Unlock()
+	return r.stats
+}
+
+// endpoint returns the first segment of the path, like "/forms"
+func endpoint(path string) string {
+	if len(path) < 2 {
+		return path
+	}
+	if i := strings.IndexAny(path[1:], "/?"); i >= 0 {
+		return path[:i+1]
+	}
+	return path
+}
+
+// bucket returns the bucket for the request, creating it if needed. Must be
+// called with the lock held.
+func (r *RateLimiter) bucket(method, path string) *bucket {
+	e := endpoint(path)
+	key := ""
+	l := r.def
+	if el, ok := r.endpoints[method+" "+e]; ok {
+		key, l = method+" "+e, el
+	} else if el, ok := r.endpoints[e]; ok {
+		key, l = e, el
+	}
+	if l.Rate <= 0 {
+		return nil
+	}
+	b, ok := r.buckets[key]
+	if !ok {
+		b = newBucket(l, r.now())
+		r.buckets[key] = b
+	}
+	return b
+}
+
+// wait takes a token for the request, waiting if necessary depending on the
+// mode
+func (r *RateLimiter) wait(ctx context.Context, method, path string) error {
+	r.l.Lock()
+	b := r.bucket(method, path)
+	if b == nil {
+		r.l.Unlock()
+		return nil
+	}
+	b.refill(r.now())
+	if b.tokens >= 1 {
+		b.tokens--
+		r.l.Unlock()
+		return nil
+	}
+	if r.mode == RateLimitFailFast {
+		r.stats.Rejected++
+		r.l.Unlock()
+		return ErrRateLimited
+	}
+	// take the token now so that other waiters queue up behind this one
+	d := time.Duration((1 - b.tokens) / b.limit.Rate * float64(time.Second))
+	b.tokens--
+	r.stats.Waits++
+	r.l.Unlock()
+
+	start := r.now()
+	err := sleepContext(ctx, d)
+
+	r.l.Lock()
+	defer r.l.Unlock()
+	r.stats.WaitTime += r.now().Sub(start)
+	if err != nil {
+		// give the token back since we never used it
+		b.tokens++
+	}
+	return err
+}
+
+// WithRateLimiter makes the Client wait for the RateLimiter before each
+// request, including retries
+func WithRateLimiter(r *RateLimiter) Option {
+	return func(c *Client) {
+		c.limiter = r
+	}
+}
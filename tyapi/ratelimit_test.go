@@ -0,0 +1,85 @@
+package tyapi
+
+import (
+	"context"
+	"github.com/levenlabs/go-typeform/tyform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"sync"
+	. "testing"
+	"time"
+)
+
+func TestEndpoint(t *T) {
+	assert.Equal(t, "/forms", endpoint("/forms"))
+	assert.Equal(t, "/forms", endpoint("/forms/abc"))
+	assert.Equal(t, "/forms", endpoint("/forms?page=1"))
+	assert.Equal(t, "/", endpoint("/"))
+}
+
+func TestRateLimiterFailFast(t *T) {
+	r := NewRateLimiter(RateLimit{Rate: 1, Burst: 2}, RateLimitFailFast)
+	now := time.Now()
+	r.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	assert.Nil(t, r.wait(ctx, "POST", "/forms"))
+	assert.Nil(t, r.wait(ctx, "GET", "/forms/abc"))
+	assert.Equal(t, ErrRateLimited, r.wait(ctx, "POST", "/forms"))
+	assert.EqualValues(t, 1, r.Stats().Rejected)
+
+	now = now.Add(time.Second)
+	assert.Nil(t, r.wait(ctx, "POST", "/forms"))
+	assert.Equal(t, ErrRateLimited, r.wait(ctx, "POST", "/forms"))
+
+	// endpoint limits have their own buckets
+	r.SetEndpointLimit("/images", RateLimit{Rate: 1})
+	r.SetEndpointLimit("GET /images", RateLimit{})
+	assert.Nil(t, r.wait(ctx, "POST", "/images"))
+	assert.Equal(t, ErrRateLimited, r.wait(ctx, "POST", "/images"))
+	for i := 0; i < 5; i++ {
+		assert.Nil(t, r.wait(ctx, "GET", "/images/abc"))
+	}
+}
+
+func TestRateLimiterBlock(t *T) {
+	r := NewRateLimiter(RateLimit{Rate: 100, Burst: 1}, RateLimitBlock)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, r.wait(ctx, "GET", "/forms"))
+		}()
+	}
+	wg.Wait()
+	assert.True(t, time.Since(start) >= 20*time.Millisecond)
+	stats := r.Stats()
+	assert.EqualValues(t, 2, stats.Waits)
+	assert.True(t, stats.WaitTime > 0)
+
+	r = NewRateLimiter(RateLimit{Rate: 0.001}, RateLimitBlock)
+	require.Nil(t, r.wait(ctx, "GET", "/forms"))
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, r.wait(cctx, "GET", "/forms"))
+}
+
+func TestClientRateLimiter(t *T) {
+	sc := &seqClient{
+		Responses: []seqResponse{
+			{StatusCode: http.StatusCreated, Body: `{"id":"random"}`},
+		},
+	}
+	r := NewRateLimiter(RateLimit{Rate: 0.001}, RateLimitFailFast)
+	c := NewClient(WithToken("test"), WithHTTPClient(sc), WithRateLimiter(r))
+	_, err := c.Create(&tyform.Form{})
+	require.Nil(t, err)
+	_, err = c.Create(&tyform.Form{})
+	assert.Equal(t, ErrRateLimited, err)
+	assert.Len(t, sc.Reqs, 1)
+}
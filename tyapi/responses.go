@@ -0,0 +1,93 @@
+package tyapi
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// ResponsesOptions select which responses are returned by Responses
+type ResponsesOptions struct {
+	ListOptions
+
+	// Since and Until limit the responses to those submitted within the
+	// range. Zero values are ignored.
+	Since time.Time
+	Until time.Time
+
+	// Completed limits the responses to those that were completed
+	Completed bool
+}
+
+// path returns the path with query parameters for the form's responses
+func (o ResponsesOptions) path(formID string) string {
+	v := o.ListOptions.values()
+	if !o.Since.IsZero() {
+		v.Set("since", o.Since.UTC().Format(time.RFC3339))
+	}
+	if !o.Until.IsZero() {
+		v.Set("until", o.Until.UTC().Format(time.RFC3339))
+	}
+	if o.Completed {
+		v.Set("completed", "true")
+	}
+	return withQuery(formPath(formID)+"/responses", v)
+}
+
+// ResultsList is a single page of results returned by Responses
+type ResultsList struct {
+	TotalItems int        `json:"total_items"`
+	PageCount  int        `json:"page_count"`
+	Items      []*Results `json:"items"`
+}
+
+// Responses fetches a single page of results for the form. This can be used
+// instead of, or in addition to, the webhook and returns the same Results
+// that are passed to the webhook handler.
+func (c *Client) Responses(formID string, o ResponsesOptions) (*ResultsList, error) {
+	return c.ResponsesContext(context.Background(), formID, o)
+}
+
+// ResponsesContext is like Responses but the request is canceled along with
+// ctx
+func (c *Client) ResponsesContext(ctx context.Context, formID string, o ResponsesOptions) (*ResultsList, error) {
+	res := &ResultsList{}
+	if err := c.do(ctx, "GET", o.path(formID), nil, http.StatusOK, res); err != nil {
+		return nil, err
+	}
+	for _, r := range res.Items {
+		sort.Sort(r.Answers)
+	}
+	return res, nil
+}
+
+// ResultsIterator iterates over all of the results returned by Responses
+type ResultsIterator struct {
+	*Iterator
+}
+
+// Results returns the current results. It's only valid after Next returns
+// true.
+func (it ResultsIterator) Results() *Results {
+	r, _ := it.Value().(*Results)
+	return r
+}
+
+// IterateResponses returns a ResultsIterator that fetches every page of results
+// for the form starting at the page described by o
+func (c *Client) IterateResponses(ctx context.Context, formID string, o ResponsesOptions) ResultsIterator {
+	return ResultsIterator{NewIterator(ctx, o.ListOptions, func(ctx context.Context, lo ListOptions) ([]interface{}, *ListOptions, error) {
+		ro := o
+		ro.ListOptions = lo
+		res, err := c.ResponsesContext(ctx, formID, ro)
+		if err != nil {
+			return nil, nil, err
+		}
+		items := make([]interface{}, len(res.Items))
+		for i := range res.Items {
+			items[i] = res.Items[i]
+		}
+		return items, nextPage(lo, res.PageCount), nil
+	})}
+}
@@ -0,0 +1,77 @@
+package tyapi
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	. "testing"
+	"time"
+)
+
+func TestResponsesPath(t *T) {
+	o := ResponsesOptions{}
+	assert.Equal(t, "/forms/abc/responses", o.path("abc"))
+
+	o = ResponsesOptions{
+		ListOptions: ListOptions{PageSize: 10},
+		Since:       time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC),
+		Until:       time.Date(2016, 2, 2, 3, 4, 5, 0, time.UTC),
+		Completed:   true,
+	}
+	assert.Equal(t, "/forms/abc/responses?completed=true&page_size=10&since=2016-01-02T03%3A04%3A05Z&until=2016-02-02T03%3A04%3A05Z", o.path("abc"))
+}
+
+func TestResponses(t *T) {
+	sc := &seqClient{
+		Responses: []seqResponse{
+			{StatusCode: http.StatusOK, Body: `{
+				"total_items": 1,
+				"page_count": 1,
+				"items": [{
+					"uid": "test",
+					"token": "t1",
+					"answers": [
+						{"field_id":123,"type":"text","value":"hey"},
+						{"field_id":122,"type":"number","value":{"amount":5}}
+					]
+				}]
+			}`},
+		},
+	}
+	c := NewClient(WithToken("test"), WithHTTPClient(sc))
+	res, err := c.Responses("abc", ResponsesOptions{Completed: true})
+	require.Nil(t, err)
+	require.Len(t, sc.Reqs, 1)
+	assert.Equal(t, "/v0.4/forms/abc/responses", sc.Reqs[0].URL.Path)
+
+	require.Len(t, res.Items, 1)
+	r := res.Items[0]
+	assert.Equal(t, "test", r.UID)
+	assert.Equal(t, "t1", r.Token)
+	require.Len(t, r.Answers, 2)
+	assert.EqualValues(t, 122, r.Answers[0].FieldID)
+	assert.Equal(t, &NumberValue{5}, r.Answers[0].Value)
+	tv := TextValue("hey")
+	assert.Equal(t, &tv, r.Answers[1].Value)
+}
+
+func TestIterateResponses(t *T) {
+	sc := &seqClient{
+		Responses: []seqResponse{
+			{StatusCode: http.StatusOK, Body: `{"page_count":2,"items":[{"token":"a"}]}`},
+			{StatusCode: http.StatusOK, Body: `{"page_count":2,"items":[{"token":"b"}]}`},
+		},
+	}
+	c := NewClient(WithToken("test"), WithHTTPClient(sc))
+	it := c.IterateResponses(context.Background(), "abc", ResponsesOptions{Completed: true})
+	var tokens []string
+	for it.Next() {
+		tokens = append(tokens, it.Results().Token)
+	}
+	require.Nil(t, it.Err())
+	assert.Equal(t, []string{"a", "b"}, tokens)
+	require.Len(t, sc.Reqs, 2)
+	assert.Equal(t, "completed=true", sc.Reqs[0].URL.RawQuery)
+	assert.Equal(t, "completed=true&page=2", sc.Reqs[1].URL.RawQuery)
+}
@@ -0,0 +1,169 @@
+package tyapi
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryAttempt describes a failed attempt that is about to be retried. It's
+// passed to RetryPolicy's OnRetry hook.
+type RetryAttempt struct {
+	Method string
+	Path   string
+
+	// Attempt is the number of the attempt that failed, starting at 1
+	Attempt int
+
+	// StatusCode is the response's status code, or 0 if Err is set
+	StatusCode int
+	Err        error
+
+	// Wait is how long we'll wait before making the next attempt
+	Wait time.Duration
+}
+
+// RetryPolicy describes when and how often requests that fail with a 429, a 5xx
+// or a network error are retried. Only idempotent requests are retried unless
+// the request's context was passed through RetrySafe.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one. A
+	// value of 1 or less disables retrying.
+	MaxAttempts int
+
+	// MinBackoff is the wait before the first retry. Each following retry
+	// waits twice as long as the previous, up to MaxBackoff. Each wait is
+	// jittered between half and all of its value. A MaxBackoff of 0 means
+	// there is no limit.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// IgnoreRetryAfter makes the policy use its backoff even if the response
+	// has a Retry-After header. Otherwise the Retry-After is waited for, unless
+	// it's longer than MaxBackoff in which case the request isn't retried.
+	IgnoreRetryAfter bool
+
+	// OnRetry, if set, is called before waiting to make each retry
+	OnRetry func(RetryAttempt)
+}
+
+// DefaultRetryPolicy is a reasonable RetryPolicy that can be passed to
+// WithRetryPolicy
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  30 * time.Second,
+}
+
+// WithRetryPolicy sets the RetryPolicy used by the client. By default requests
+// are not retried.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = p
+	}
+}
+
+type retrySafeKey struct{}
+
+// RetrySafe returns a context that marks a request as safe to retry even if
+// its method isn't idempotent, like the POST made by CreateContext
+func RetrySafe(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retrySafeKey{}, true)
+}
+
+func isRetrySafe(ctx context.Context, method string) bool {
+	switch method {
+	case "GET", "HEAD", "PUT", "DELETE", "OPTIONS":
+		return true
+	}
+	safe, _ := ctx.Value(retrySafeKey{}).(bool)
+	return safe
+}
+
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns how long to wait after the given failed attempt, or false
+// if the response's Retry-After is longer than MaxBackoff
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil && !p.IgnoreRetryAfter {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if p.MaxBackoff > 0 && d > p.MaxBackoff {
+				return 0, false
+			}
+			return d, true
+		}
+	}
+
+	d := p.MinBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0, true
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1)), true
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of
+// seconds or an http date
+func parseRetryAfter(h string) (time.Duration, bool) {
+	if h == "" {
+		return 0, false
+	}
+	if s, err := strconv.ParseInt(h, 10, 64); err == nil {
+		if s < 0 {
+			return 0, false
+		}
+		return time.Duration(s) * time.Second, true
+	}
+	t, err := http.ParseTime(h)
+	if err != nil {
+		return 0, false
+	}
+	d := time.Until(t)
+	if d < 0 {
+		d = 0
+	}
+	return d, true
+}
+
+// shouldRetry returns whether the given attempt should be retried based on its
+// response or error, and if so how long to wait first
+func (p RetryPolicy) shouldRetry(ctx context.Context, method string, attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts || ctx.Err() != nil || !isRetrySafe(ctx, method) {
+		return 0, false
+	}
+	if err == nil && !isRetryableStatus(resp.StatusCode) {
+		return 0, false
+	}
+	return p.backoff(attempt, resp)
+}
+
+// sleepContext waits for d or until ctx is canceled, in which case the
+// context's error is returned
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
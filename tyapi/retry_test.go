@@ -0,0 +1,201 @@
+package tyapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/levenlabs/go-typeform/tyform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"net/http"
+	. "testing"
+	"time"
+)
+
+type seqResponse struct {
+	StatusCode    int
+	Body          string
+	Header        http.Header
+	ContentLength int64
+	Err           error
+}
+
+// seqClient responds to each request with the next response in Responses
+type seqClient struct {
+	Responses []seqResponse
+	Reqs      []*http.Request
+}
+
+func (s *seqClient) Do(r *http.Request) (*http.Response, error) {
+	sr := s.Responses[len(s.Reqs)]
+	s.Reqs = append(s.Reqs, r)
+	if sr.Err != nil {
+		return nil, sr.Err
+	}
+	h := sr.Header
+	if h == nil {
+		h = http.Header{}
+	}
+	return &http.Response{
+		StatusCode:    sr.StatusCode,
+		Header:        h,
+		ContentLength: sr.ContentLength,
+		Body:          ioutil.NopCloser(bytes.NewBufferString(sr.Body)),
+	}, nil
+}
+
+func TestRetry(t *T) {
+	sc := &seqClient{
+		Responses: []seqResponse{
+			{StatusCode: http.StatusServiceUnavailable},
+			{Err: errors.New("connection reset")},
+			{StatusCode: http.StatusCreated, Body: `{"id":"random"}`},
+		},
+	}
+	var attempts []RetryAttempt
+	c := NewClient(WithToken("test"), WithHTTPClient(sc), WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 3,
+		OnRetry: func(ra RetryAttempt) {
+			attempts = append(attempts, ra)
+		},
+	}))
+
+	// POST isn't retried unless it's marked safe
+	_, err := c.Create(&tyform.Form{})
+	require.NotNil(t, err)
+	assert.Len(t, sc.Reqs, 1)
+	assert.Len(t, attempts, 0)
+
+	sc.Reqs = nil
+	res, err := c.CreateContext(RetrySafe(context.Background()), &tyform.Form{})
+	require.Nil(t, err)
+	assert.Equal(t, "random", res.ID)
+	require.Len(t, sc.Reqs, 3)
+	require.Len(t, attempts, 2)
+	assert.Equal(t, 1, attempts[0].Attempt)
+	assert.Equal(t, http.StatusServiceUnavailable, attempts[0].StatusCode)
+	assert.Equal(t, "/forms", attempts[0].Path)
+	assert.Equal(t, 2, attempts[1].Attempt)
+	assert.NotNil(t, attempts[1].Err)
+
+	// every attempt should send the full body
+	for _, r := range sc.Reqs {
+		b, err := ioutil.ReadAll(r.Body)
+		require.Nil(t, err)
+		assert.Equal(t, `{"title":"","fields":null}`, string(b))
+	}
+
+	// stops after MaxAttempts
+	sc = &seqClient{
+		Responses: []seqResponse{
+			{StatusCode: http.StatusTooManyRequests},
+			{StatusCode: http.StatusTooManyRequests},
+		},
+	}
+	c = NewClient(WithToken("test"), WithHTTPClient(sc), WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 2,
+	}))
+	_, err = c.CreateContext(RetrySafe(context.Background()), &tyform.Form{})
+	require.NotNil(t, err)
+	assert.Len(t, sc.Reqs, 2)
+
+	// a 400 is never retried
+	sc = &seqClient{
+		Responses: []seqResponse{
+			{StatusCode: http.StatusBadRequest},
+		},
+	}
+	c = NewClient(WithToken("test"), WithHTTPClient(sc), WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 2,
+	}))
+	_, err = c.CreateContext(RetrySafe(context.Background()), &tyform.Form{})
+	require.NotNil(t, err)
+	assert.Len(t, sc.Reqs, 1)
+}
+
+func TestRetryContext(t *T) {
+	sc := &seqClient{
+		Responses: []seqResponse{
+			{StatusCode: http.StatusServiceUnavailable},
+		},
+	}
+	c := NewClient(WithToken("test"), WithHTTPClient(sc), WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 2,
+		MinBackoff:  time.Hour,
+	}))
+	ctx, cancel := context.WithTimeout(RetrySafe(context.Background()), 10*time.Millisecond)
+	defer cancel()
+	_, err := c.CreateContext(ctx, &tyform.Form{})
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Len(t, sc.Reqs, 1)
+}
+
+func TestRetryBackoff(t *T) {
+	p := RetryPolicy{
+		MinBackoff: time.Second,
+		MaxBackoff: 4 * time.Second,
+	}
+	for i, exp := range []time.Duration{1, 2, 4, 4, 4} {
+		exp *= time.Second
+		d, ok := p.backoff(i+1, nil)
+		assert.True(t, ok)
+		assert.True(t, d >= exp/2, "attempt %d: %s", i+1, d)
+		assert.True(t, d <= exp, "attempt %d: %s", i+1, d)
+	}
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", "3")
+	d, ok := p.backoff(1, resp)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, d)
+
+	// a Retry-After longer than MaxBackoff isn't waited for
+	resp.Header.Set("Retry-After", "86400")
+	_, ok = p.backoff(1, resp)
+	assert.False(t, ok)
+
+	p.IgnoreRetryAfter = true
+	d, ok = p.backoff(1, resp)
+	assert.True(t, ok)
+	assert.True(t, d <= time.Second)
+
+	// without a MaxBackoff the Retry-After is always used
+	p = RetryPolicy{MinBackoff: time.Second}
+	resp.Header.Set("Retry-After", "120")
+	d, ok = p.backoff(1, resp)
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, d)
+}
+
+func TestRetryAfterTooLong(t *T) {
+	h := http.Header{}
+	h.Set("Retry-After", "86400")
+	sc := &seqClient{
+		Responses: []seqResponse{
+			{StatusCode: http.StatusTooManyRequests, Header: h},
+			{StatusCode: http.StatusOK, Body: `{"id":"random"}`},
+		},
+	}
+	c := NewClient(WithToken("test"), WithHTTPClient(sc), WithRetryPolicy(DefaultRetryPolicy))
+	_, err := c.GetForm("random")
+	assert.True(t, errors.Is(err, ErrTooManyRequests))
+	assert.Len(t, sc.Reqs, 1)
+}
+
+func TestParseRetryAfter(t *T) {
+	d, ok := parseRetryAfter("5")
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, d)
+
+	d, ok = parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), d)
+
+	_, ok = parseRetryAfter("")
+	assert.False(t, ok)
+	_, ok = parseRetryAfter("soon")
+	assert.False(t, ok)
+	_, ok = parseRetryAfter("-1")
+	assert.False(t, ok)
+}
@@ -2,14 +2,18 @@ package tyapi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/levenlabs/go-llog"
+	"github.com/levenlabs/go-typeform/tyform"
 	"gopkg.in/mgo.v2/bson"
+	"net"
 	"net/http"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // ResultsAnswerSlice implements the sort interface and sorts by FieldID
@@ -22,6 +26,9 @@ type Results struct {
 	UID     string             `json:"uid"          bson:"i"`
 	Token   string             `json:"token"        bson:"t"`
 	Answers ResultsAnswerSlice `json:"answers"      bson:"a"`
+
+	// Hidden contains the values of the form's hidden fields keyed by name
+	Hidden map[string]string `json:"hidden,omitempty" bson:"h,omitempty"`
 }
 
 // ResultsAnswerMetadata is shared between the different forms of the answer
@@ -67,6 +74,12 @@ type jsonNumberValue struct {
 	Amount json.Number `json:"amount"`
 }
 
+// RatingValue represents a rating answer. It's kept separate from NumberValue
+// so ratings can be told apart from plain numbers.
+type RatingValue struct {
+	Amount int64 `json:"amount"                     bson:"a"`
+}
+
 // ChoiceValue represents a number answer
 type ChoiceValue struct {
 	Label      string `json:"label"                 bson:"l,omitempty"`
@@ -90,10 +103,130 @@ type jsonChoicesValue struct {
 	Other  json.RawMessage `json:"other,omitempty"`
 }
 
+// FileValue represents a file upload answer
+type FileValue struct {
+	URL         string `json:"file_url"             bson:"u"`
+	Name        string `json:"file_name"            bson:"n"`
+	Size        int64  `json:"file_size"            bson:"s"`
+	ContentType string `json:"content_type"         bson:"c"`
+}
+
+// jsonFileValue is needed because json has to use json.Number
+type jsonFileValue struct {
+	URL         string      `json:"file_url"`
+	Name        string      `json:"file_name"`
+	Size        json.Number `json:"file_size"`
+	ContentType string      `json:"content_type"`
+}
+
+// PaymentValue represents a payment answer. Webhooks don't include the
+// currency, so Amount is kept as the decimal that was received, like "10.50",
+// in the currency's major units. Use MinorAmount with the Currency of the
+// form's tyform.Payment field to get it in minor units, like cents.
+type PaymentValue struct {
+	Amount  json.Number `json:"amount"                  bson:"a"`
+	Last4   string      `json:"last4"                   bson:"l4"`
+	Name    string      `json:"name"                    bson:"n"`
+	Success bool        `json:"success"                 bson:"s"`
+}
+
+// bsonPaymentValue is needed because bson stores a json.Number as a float
+type bsonPaymentValue struct {
+	Amount  string `bson:"a"`
+	Last4   string `bson:"l4"`
+	Name    string `bson:"n"`
+	Success bool   `bson:"s"`
+}
+
+// GetBSON implements the bson.Getter interface. The Amount is stored as a
+// string so it's never converted to a float.
+func (v PaymentValue) GetBSON() (interface{}, error) {
+	return bsonPaymentValue{
+		Amount:  string(v.Amount),
+		Last4:   v.Last4,
+		Name:    v.Name,
+		Success: v.Success,
+	}, nil
+}
+
+// SetBSON implements the bson.Setter interface
+func (v *PaymentValue) SetBSON(raw bson.Raw) error {
+	bv := bsonPaymentValue{}
+	if err := raw.Unmarshal(&bv); err != nil {
+		return err
+	}
+	*v = PaymentValue{
+		Amount:  json.Number(bv.Amount),
+		Last4:   bv.Last4,
+		Name:    bv.Name,
+		Success: bv.Success,
+	}
+	return nil
+}
+
+// MinorAmount returns the Amount in the minor units of the given currency,
+// like cents. An error is returned if the Amount has more decimal places than
+// the currency allows.
+func (v *PaymentValue) MinorAmount(currency string) (int64, error) {
+	if v.Amount == "" {
+		return 0, nil
+	}
+	return tyform.ParseAmount(string(v.Amount), currency)
+}
+
 type TextValue string
 
+// EmailValue represents an email answer
+type EmailValue string
+
+// URLValue represents a website answer
+type URLValue string
+
 type BooleanValue bool
 
+// dateFormat is the format typeform uses for date answers
+const dateFormat = "2006-01-02"
+
+// DateValue represents a date answer. It's stored in json as typeform sends it
+// and as a native date in bson.
+type DateValue struct {
+	time.Time
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (v DateValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Format(dateFormat))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (v *DateValue) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	t, err := time.Parse(dateFormat, s)
+	if err != nil {
+		return err
+	}
+	v.Time = t
+	return nil
+}
+
+// GetBSON implements the bson.Getter interface
+func (v DateValue) GetBSON() (interface{}, error) {
+	return v.Time, nil
+}
+
+// SetBSON implements the bson.Setter interface
+func (v *DateValue) SetBSON(raw bson.Raw) error {
+	var t time.Time
+	if err := raw.Unmarshal(&t); err != nil {
+		return err
+	}
+	v.Time = t.UTC()
+	return nil
+}
+
 // ListenAndServe starts an http server at the given addr and requires a handler
 // that will be called for each webhook request and passed a Results pointer. If
 // an error is returned from the handler, a 500 response is sent and TypeForm
@@ -103,7 +236,59 @@ type BooleanValue bool
 // You should store the Token for each call and verify you haven't already
 // processed it.
 func ListenAndServe(addr string, cb func(*Results, *http.Request) error) error {
-	return http.ListenAndServe(addr, http.HandlerFunc(wrapCallback(cb)))
+	return ListenAndServeContext(context.Background(), addr, cb)
+}
+
+// ShutdownTimeout is how long ListenAndServeContext waits for running handlers
+// to finish once its context is canceled
+var ShutdownTimeout = 30 * time.Second
+
+// ListenAndServeContext is like ListenAndServe except the server is shutdown
+// when ctx is canceled. It then waits up to ShutdownTimeout for the running
+// handlers to finish before returning, and returns nil if they all did. The
+// context of each request passed to the handler is canceled when the client
+// disconnects or when ctx is canceled, so handlers should watch r.Context().
+func ListenAndServeContext(ctx context.Context, addr string, cb func(*Results, *http.Request) error) error {
+	if addr == "" {
+		addr = ":http"
+	}
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return serveContext(ctx, l, cb)
+}
+
+// serveContext serves the webhook on l until ctx is canceled, then waits for
+// the server to shutdown
+func serveContext(ctx context.Context, l net.Listener, cb func(*Results, *http.Request) error) error {
+	srv := &http.Server{
+		Handler: http.HandlerFunc(wrapCallback(cb)),
+		BaseContext: func(net.Listener) context.Context {
+			return ctx
+		},
+	}
+
+	done := make(chan struct{})
+	shutdownErr := make(chan error, 1)
+	go func() {
+		select {
+		case <-ctx.Done():
+			sctx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+			defer cancel()
+			shutdownErr <- srv.Shutdown(sctx)
+		case <-done:
+			shutdownErr <- nil
+		}
+	}()
+
+	err := srv.Serve(l)
+	close(done)
+	// Serve returns as soon as Shutdown starts so wait for it to finish
+	if serr := <-shutdownErr; err == http.ErrServerClosed {
+		return serr
+	}
+	return err
 }
 
 func wrapCallback(cb func(*Results, *http.Request) error) func(http.ResponseWriter, *http.Request) {
@@ -127,6 +312,12 @@ func wrapCallback(cb func(*Results, *http.Request) error) func(http.ResponseWrit
 			return
 		}
 		sort.Sort(res.Answers)
+		if err := r.Context().Err(); err != nil {
+			kv["error"] = err
+			llog.Warn("webhook request canceled before handling", kv)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
 		err := cb(res, r)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -136,6 +327,42 @@ func wrapCallback(cb func(*Results, *http.Request) error) func(http.ResponseWrit
 	}
 }
 
+// AnswersOfType returns all of the answers that have the given type, like
+// "legal"
+func (r *Results) AnswersOfType(t string) []ResultsAnswer {
+	var as []ResultsAnswer
+	for _, a := range r.Answers {
+		if a.Type == t {
+			as = append(as, a)
+		}
+	}
+	return as
+}
+
+// LegalAnswers returns all of the answers to Legal fields. The Value of each
+// is a *BooleanValue which is true if the terms were accepted.
+func (r *Results) LegalAnswers() []ResultsAnswer {
+	return r.AnswersOfType("legal")
+}
+
+// HiddenValue returns the value of the hidden field with the given name and
+// whether it was included in the Results
+func (r *Results) HiddenValue(name string) (string, bool) {
+	v, ok := r.Hidden[name]
+	return v, ok
+}
+
+// HiddenNames returns the names of the hidden fields included in the Results,
+// sorted
+func (r *Results) HiddenNames() []string {
+	names := make([]string, 0, len(r.Hidden))
+	for n := range r.Hidden {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // Len implements the sort interface
 func (s ResultsAnswerSlice) Len() int {
 	return len(s)
@@ -158,14 +385,38 @@ func (a *ResultsAnswer) emptyValue(forJSON bool) interface{} {
 			return &jsonNumberValue{}
 		}
 		return &NumberValue{}
+	case "rating":
+		if forJSON {
+			return &jsonNumberValue{}
+		}
+		return &RatingValue{}
 	case "choice":
 		return &ChoiceValue{}
 	case "choices":
 		return &ChoicesValue{}
+	case "picture_choice":
+		// picture choices are switched to ChoicesValue by the callers if the
+		// answer has multiple labels
+		return &ChoiceValue{}
 	case "text":
 		return new(TextValue)
-	case "boolean":
+	case "boolean", "legal":
+		// legal answers keep their type so they can be told apart from
+		// yes/no answers
 		return new(BooleanValue)
+	case "email":
+		return new(EmailValue)
+	case "url":
+		return new(URLValue)
+	case "date":
+		return &DateValue{}
+	case "payment":
+		return &PaymentValue{}
+	case "file":
+		if forJSON {
+			return &jsonFileValue{}
+		}
+		return &FileValue{}
 	}
 	return nil
 }
@@ -201,6 +452,9 @@ func (a *ResultsAnswer) UnmarshalJSON(b []byte) error {
 	}
 
 	a.Value = a.emptyValue(true)
+	if a.Type == "picture_choice" && hasJSONLabels(ja.Value) {
+		a.Value = &ChoicesValue{}
+	}
 	if a.Value != nil {
 		d = json.NewDecoder(bytes.NewReader(ja.Value))
 		d.UseNumber()
@@ -213,7 +467,24 @@ func (a *ResultsAnswer) UnmarshalJSON(b []byte) error {
 			if err != nil {
 				return err
 			}
-			a.Value = &NumberValue{nv}
+			if a.Type == "rating" {
+				a.Value = &RatingValue{nv}
+			} else {
+				a.Value = &NumberValue{nv}
+			}
+		case *jsonFileValue:
+			var size int64
+			if v.Size != "" {
+				if size, err = numberToInt64(v.Size); err != nil {
+					return err
+				}
+			}
+			a.Value = &FileValue{
+				URL:         v.URL,
+				Name:        v.Name,
+				Size:        size,
+				ContentType: v.ContentType,
+			}
 		}
 	}
 	return nil
@@ -228,6 +499,9 @@ func (a *ResultsAnswer) SetBSON(raw bson.Raw) error {
 	a.ResultsAnswerMetadata = ba.ResultsAnswerMetadata
 
 	a.Value = a.emptyValue(false)
+	if a.Type == "picture_choice" && hasBSONLabels(ba.Value) {
+		a.Value = &ChoicesValue{}
+	}
 	if a.Value != nil {
 		if err := ba.Value.Unmarshal(a.Value); err != nil {
 			return err
@@ -236,6 +510,30 @@ func (a *ResultsAnswer) SetBSON(raw bson.Raw) error {
 	return nil
 }
 
+// hasJSONLabels returns true if the json value has a labels key, meaning it's
+// a ChoicesValue
+func hasJSONLabels(m json.RawMessage) bool {
+	v := map[string]json.RawMessage{}
+	if err := json.Unmarshal(m, &v); err != nil {
+		return false
+	}
+	_, ok := v["labels"]
+	return ok
+}
+
+// hasBSONLabels returns true if the bson value's labels are an array, meaning
+// it's a ChoicesValue
+func hasBSONLabels(raw bson.Raw) bool {
+	v := struct {
+		Labels bson.Raw `bson:"l"`
+	}{}
+	if err := raw.Unmarshal(&v); err != nil {
+		return false
+	}
+	// 0x04 is the bson kind for an array
+	return v.Labels.Kind == 0x04
+}
+
 // String returns a string version of the answer
 func (a *ResultsAnswer) String() string {
 	if a.Value == nil {
@@ -244,6 +542,8 @@ func (a *ResultsAnswer) String() string {
 	switch v := a.Value.(type) {
 	case *NumberValue:
 		return strconv.FormatInt(v.Amount, 10)
+	case *RatingValue:
+		return strconv.FormatInt(v.Amount, 10)
 	case *BooleanValue:
 		if bool(*v) {
 			return "true"
@@ -262,6 +562,16 @@ func (a *ResultsAnswer) String() string {
 		return strings.Join(v.Labels, ",")
 	case *TextValue:
 		return string(*v)
+	case *EmailValue:
+		return string(*v)
+	case *URLValue:
+		return string(*v)
+	case *DateValue:
+		return v.Format(dateFormat)
+	case *FileValue:
+		return v.URL
+	case *PaymentValue:
+		return string(v.Amount)
 	default:
 		llog.Error(fmt.Sprintf("encountered unknown type in stringValue: %T", a))
 	}
@@ -2,17 +2,21 @@ package tyapi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/mgo.v2/bson"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"sort"
+	"sync/atomic"
 	. "testing"
+	"time"
 )
 
 func TestJSONNumber(t *T) {
@@ -78,6 +82,46 @@ func TestBSONNumber(t *T) {
 	assert.EqualValues(t, a, na)
 }
 
+func TestJSONRating(t *T) {
+	a := &ResultsAnswer{
+		Value: &RatingValue{
+			Amount: 4,
+		},
+	}
+	a.Type = "rating"
+	fs := `{"field_id":0,"type":"rating","value":{"amount":4}}`
+	j, err := json.Marshal(a)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	na := &ResultsAnswer{}
+	err = json.Unmarshal(j, na)
+	require.Nil(t, err)
+	assert.EqualValues(t, a, na)
+
+	fs = `{"field_id":0,"type":"rating","value":{"amount":4e+00}}`
+	na = &ResultsAnswer{}
+	err = json.Unmarshal([]byte(fs), na)
+	require.Nil(t, err)
+	assert.Equal(t, &RatingValue{4}, na.Value)
+}
+
+func TestBSONRating(t *T) {
+	a := &ResultsAnswer{
+		Value: &RatingValue{
+			Amount: 4,
+		},
+	}
+	a.Type = "rating"
+	j, err := bson.Marshal(a)
+	require.Nil(t, err)
+
+	na := &ResultsAnswer{}
+	err = bson.Unmarshal(j, na)
+	require.Nil(t, err)
+	assert.EqualValues(t, a, na)
+}
+
 func TestJSONBoolean(t *T) {
 	v := BooleanValue(true)
 	a := &ResultsAnswer{
@@ -102,6 +146,84 @@ func TestJSONBoolean(t *T) {
 	assert.Equal(t, &v, na.Value)
 }
 
+func TestJSONLegal(t *T) {
+	v := BooleanValue(true)
+	a := &ResultsAnswer{
+		Value: &v,
+	}
+	a.Type = "legal"
+	fs := `{"field_id":0,"type":"legal","value":true}`
+	j, err := json.Marshal(a)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	na := &ResultsAnswer{}
+	err = json.Unmarshal(j, na)
+	require.Nil(t, err)
+	assert.EqualValues(t, a, na)
+
+	j, err = bson.Marshal(a)
+	require.Nil(t, err)
+	na = &ResultsAnswer{}
+	err = bson.Unmarshal(j, na)
+	require.Nil(t, err)
+	assert.EqualValues(t, a, na)
+}
+
+func TestLegalAnswers(t *T) {
+	b := []byte(`{
+		"answers": [
+			{"field_id":1,"type":"boolean","value":true},
+			{"field_id":2,"type":"legal","value":false},
+			{"field_id":3,"type":"legal","value":true}
+		]
+	}`)
+	r := &Results{}
+	require.Nil(t, json.Unmarshal(b, r))
+	la := r.LegalAnswers()
+	require.Len(t, la, 2)
+	assert.EqualValues(t, 2, la[0].FieldID)
+	f := BooleanValue(false)
+	assert.Equal(t, &f, la[0].Value)
+	assert.EqualValues(t, 3, la[1].FieldID)
+	assert.Len(t, r.AnswersOfType("boolean"), 1)
+}
+
+func TestHidden(t *T) {
+	b := []byte(`{
+		"uid": "test",
+		"answers": [],
+		"hidden": {"user_id":"42","source":"email"}
+	}`)
+	r := &Results{}
+	require.Nil(t, json.Unmarshal(b, r))
+	v, ok := r.HiddenValue("user_id")
+	assert.True(t, ok)
+	assert.Equal(t, "42", v)
+	_, ok = r.HiddenValue("campaign")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"source", "user_id"}, r.HiddenNames())
+
+	bb, err := bson.Marshal(r)
+	require.Nil(t, err)
+	m := bson.M{}
+	require.Nil(t, bson.Unmarshal(bb, &m))
+	assert.Equal(t, bson.M{"user_id": "42", "source": "email"}, m["h"])
+
+	nr := &Results{}
+	require.Nil(t, bson.Unmarshal(bb, nr))
+	assert.Equal(t, r.Hidden, nr.Hidden)
+
+	// no hidden fields are omitted
+	bb, err = bson.Marshal(&Results{})
+	require.Nil(t, err)
+	m = bson.M{}
+	require.Nil(t, bson.Unmarshal(bb, &m))
+	_, ok = m["h"]
+	assert.False(t, ok)
+	assert.Empty(t, (&Results{}).HiddenNames())
+}
+
 func TestBSONBoolean(t *T) {
 	v := BooleanValue(true)
 	a := &ResultsAnswer{
@@ -178,6 +300,259 @@ func TestBSONText(t *T) {
 	assert.EqualValues(t, a, na)
 }
 
+func TestJSONEmail(t *T) {
+	v := EmailValue("a@example.com")
+	a := &ResultsAnswer{
+		Value: &v,
+	}
+	a.Type = "email"
+	fs := `{"field_id":0,"type":"email","value":"a@example.com"}`
+	j, err := json.Marshal(a)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	na := &ResultsAnswer{}
+	err = json.Unmarshal(j, na)
+	require.Nil(t, err)
+	assert.EqualValues(t, a, na)
+}
+
+func TestBSONEmail(t *T) {
+	v := EmailValue("a@example.com")
+	a := &ResultsAnswer{
+		Value: &v,
+	}
+	a.Type = "email"
+	j, err := bson.Marshal(a)
+	require.Nil(t, err)
+
+	na := &ResultsAnswer{}
+	err = bson.Unmarshal(j, na)
+	require.Nil(t, err)
+	assert.EqualValues(t, a, na)
+}
+
+func TestJSONURL(t *T) {
+	v := URLValue("http://example.com")
+	a := &ResultsAnswer{
+		Value: &v,
+	}
+	a.Type = "url"
+	fs := `{"field_id":0,"type":"url","value":"http://example.com"}`
+	j, err := json.Marshal(a)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	na := &ResultsAnswer{}
+	err = json.Unmarshal(j, na)
+	require.Nil(t, err)
+	assert.EqualValues(t, a, na)
+}
+
+func TestBSONURL(t *T) {
+	v := URLValue("http://example.com")
+	a := &ResultsAnswer{
+		Value: &v,
+	}
+	a.Type = "url"
+	j, err := bson.Marshal(a)
+	require.Nil(t, err)
+
+	na := &ResultsAnswer{}
+	err = bson.Unmarshal(j, na)
+	require.Nil(t, err)
+	assert.EqualValues(t, a, na)
+}
+
+func TestJSONDate(t *T) {
+	a := &ResultsAnswer{
+		Value: &DateValue{time.Date(2016, 5, 20, 0, 0, 0, 0, time.UTC)},
+	}
+	a.Type = "date"
+	fs := `{"field_id":0,"type":"date","value":"2016-05-20"}`
+	j, err := json.Marshal(a)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	na := &ResultsAnswer{}
+	err = json.Unmarshal(j, na)
+	require.Nil(t, err)
+	assert.EqualValues(t, a, na)
+
+	fs = `{"field_id":0,"type":"date","value":"20/05/2016"}`
+	na = &ResultsAnswer{}
+	err = json.Unmarshal([]byte(fs), na)
+	assert.NotNil(t, err)
+}
+
+func TestBSONDate(t *T) {
+	d := time.Date(2016, 5, 20, 0, 0, 0, 0, time.UTC)
+	a := &ResultsAnswer{
+		Value: &DateValue{d},
+	}
+	a.Type = "date"
+	aexp := struct {
+		FieldID int64       `bson:"i"`
+		Type    string      `bson:"t"`
+		Value   interface{} `bson:"v"`
+	}{
+		Type:  "date",
+		Value: d,
+	}
+	j, err := bson.Marshal(a)
+	require.Nil(t, err)
+	jexp, err := bson.Marshal(aexp)
+	require.Nil(t, err)
+	assert.Equal(t, string(jexp), string(j))
+
+	na := &ResultsAnswer{}
+	err = bson.Unmarshal(j, na)
+	require.Nil(t, err)
+	assert.EqualValues(t, a, na)
+}
+
+func TestJSONFile(t *T) {
+	a := &ResultsAnswer{
+		Value: &FileValue{
+			URL:         "http://example.com/a.pdf",
+			Name:        "a.pdf",
+			Size:        1111111,
+			ContentType: "application/pdf",
+		},
+	}
+	a.Type = "file"
+	fs := `{"field_id":0,"type":"file","value":{"file_url":"http://example.com/a.pdf","file_name":"a.pdf","file_size":1111111,"content_type":"application/pdf"}}`
+	j, err := json.Marshal(a)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	na := &ResultsAnswer{}
+	err = json.Unmarshal(j, na)
+	require.Nil(t, err)
+	assert.EqualValues(t, a, na)
+
+	fs = `{"field_id":0,"type":"file","value":{"file_url":"http://example.com/a.pdf","file_name":"a.pdf","file_size":1.111111e+06,"content_type":"application/pdf"}}`
+	na = &ResultsAnswer{}
+	err = json.Unmarshal([]byte(fs), na)
+	require.Nil(t, err)
+	assert.EqualValues(t, a, na)
+}
+
+func TestBSONFile(t *T) {
+	a := &ResultsAnswer{
+		Value: &FileValue{
+			URL:         "http://example.com/a.pdf",
+			Name:        "a.pdf",
+			Size:        100,
+			ContentType: "application/pdf",
+		},
+	}
+	a.Type = "file"
+	j, err := bson.Marshal(a)
+	require.Nil(t, err)
+
+	na := &ResultsAnswer{}
+	err = bson.Unmarshal(j, na)
+	require.Nil(t, err)
+	assert.EqualValues(t, a, na)
+}
+
+func TestJSONPayment(t *T) {
+	a := &ResultsAnswer{
+		Value: &PaymentValue{
+			Amount:  "10.50",
+			Last4:   "4242",
+			Name:    "John",
+			Success: true,
+		},
+	}
+	a.Type = "payment"
+	fs := `{"field_id":0,"type":"payment","value":{"amount":10.50,"last4":"4242","name":"John","success":true}}`
+	j, err := json.Marshal(a)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	na := &ResultsAnswer{}
+	err = json.Unmarshal(j, na)
+	require.Nil(t, err)
+	assert.EqualValues(t, a, na)
+	assert.Equal(t, "10.50", na.String())
+
+	// the amount is kept as it was received until the currency is known
+	fs = `{"field_id":0,"type":"payment","value":{"amount":1.111111e+06,"last4":"4242","name":"John","success":true}}`
+	na = &ResultsAnswer{}
+	err = json.Unmarshal([]byte(fs), na)
+	require.Nil(t, err)
+	v, ok := na.Value.(*PaymentValue)
+	require.True(t, ok)
+	assert.Equal(t, json.Number("1.111111e+06"), v.Amount)
+	m, err := v.MinorAmount("USD")
+	require.Nil(t, err)
+	assert.EqualValues(t, 111111100, m)
+}
+
+func TestPaymentMinorAmount(t *T) {
+	v := &PaymentValue{Amount: "1000"}
+	m, err := v.MinorAmount("JPY")
+	require.Nil(t, err)
+	assert.EqualValues(t, 1000, m)
+	m, err = v.MinorAmount("USD")
+	require.Nil(t, err)
+	assert.EqualValues(t, 100000, m)
+
+	v.Amount = "1.234"
+	m, err = v.MinorAmount("KWD")
+	require.Nil(t, err)
+	assert.EqualValues(t, 1234, m)
+	_, err = v.MinorAmount("USD")
+	assert.NotNil(t, err)
+	_, err = v.MinorAmount("JPY")
+	assert.NotNil(t, err)
+
+	v.Amount = ""
+	m, err = v.MinorAmount("USD")
+	require.Nil(t, err)
+	assert.EqualValues(t, 0, m)
+}
+
+func TestBSONPayment(t *T) {
+	a := &ResultsAnswer{
+		Value: &PaymentValue{
+			Amount:  "0.29",
+			Last4:   "4242",
+			Name:    "John",
+			Success: true,
+		},
+	}
+	a.Type = "payment"
+	j, err := bson.Marshal(a)
+	require.Nil(t, err)
+
+	na := &ResultsAnswer{}
+	err = bson.Unmarshal(j, na)
+	require.Nil(t, err)
+	assert.EqualValues(t, a, na)
+
+	// amounts are stored as strings so they stay exact
+	for _, amt := range []json.Number{"10.50", "92233720368547758.07"} {
+		a.Value = &PaymentValue{Amount: amt}
+		j, err = bson.Marshal(a)
+		require.Nil(t, err)
+		m := bson.M{}
+		require.Nil(t, bson.Unmarshal(j, &m))
+		assert.Equal(t, string(amt), m["v"].(bson.M)["a"])
+
+		na = &ResultsAnswer{}
+		require.Nil(t, bson.Unmarshal(j, na))
+		v, ok := na.Value.(*PaymentValue)
+		require.True(t, ok)
+		assert.Equal(t, amt, v.Amount)
+	}
+	m, err := na.Value.(*PaymentValue).MinorAmount("USD")
+	require.Nil(t, err)
+	assert.EqualValues(t, int64(9223372036854775807), m)
+}
+
 func TestJSONChoice(t *T) {
 	a := &ResultsAnswer{
 		Value: &ChoiceValue{
@@ -460,6 +835,51 @@ func TestBSONChoices(t *T) {
 	assert.EqualValues(t, a, na)
 }
 
+func TestJSONPictureChoice(t *T) {
+	fs := `{"field_id":0,"type":"picture_choice","value":{"label":"val"}}`
+	na := &ResultsAnswer{}
+	err := json.Unmarshal([]byte(fs), na)
+	require.Nil(t, err)
+	assert.Equal(t, &ChoiceValue{Label: "val", EmptyOther: true}, na.Value)
+
+	fs = `{"field_id":0,"type":"picture_choice","value":{"labels":["a","b"],"other":"o"}}`
+	na = &ResultsAnswer{}
+	err = json.Unmarshal([]byte(fs), na)
+	require.Nil(t, err)
+	assert.Equal(t, &ChoicesValue{Labels: []string{"a", "b"}, Other: "o"}, na.Value)
+	assert.Equal(t, "o", na.String())
+}
+
+func TestBSONPictureChoice(t *T) {
+	a := &ResultsAnswer{
+		Value: &ChoiceValue{
+			Label: "val",
+		},
+	}
+	a.Type = "picture_choice"
+	j, err := bson.Marshal(a)
+	require.Nil(t, err)
+
+	na := &ResultsAnswer{}
+	err = bson.Unmarshal(j, na)
+	require.Nil(t, err)
+	assert.EqualValues(t, a, na)
+
+	a = &ResultsAnswer{
+		Value: &ChoicesValue{
+			Labels: []string{"a", "b"},
+		},
+	}
+	a.Type = "picture_choice"
+	j, err = bson.Marshal(a)
+	require.Nil(t, err)
+
+	na = &ResultsAnswer{}
+	err = bson.Unmarshal(j, na)
+	require.Nil(t, err)
+	assert.EqualValues(t, a, na)
+}
+
 func TestWrapCallback(t *T) {
 	b := []byte(`{
 		"uid": "test",
@@ -527,6 +947,94 @@ func TestWrapCallback(t *T) {
 	})(r, req)
 	assert.Equal(t, http.StatusInternalServerError, r.Code)
 	assert.Equal(t, 0, r.Body.Len())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r = httptest.NewRecorder()
+	req = (&http.Request{
+		Method: "POST",
+		Body:   ioutil.NopCloser(bytes.NewBuffer(b)),
+		URL:    u,
+	}).WithContext(ctx)
+	wrapCallback(func(r *Results, _ *http.Request) error {
+		// this should never run
+		require.True(t, false)
+		return nil
+	})(r, req)
+	assert.Equal(t, http.StatusServiceUnavailable, r.Code)
+}
+
+func TestListenAndServeContext(t *T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error)
+	go func() {
+		errCh <- ListenAndServeContext(ctx, "127.0.0.1:0", func(*Results, *http.Request) error {
+			return nil
+		})
+	}()
+	cancel()
+	assert.Nil(t, <-errCh)
+}
+
+func TestListenAndServeContextInFlight(t *T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	var finished int32
+	errCh := make(chan error)
+	go func() {
+		errCh <- serveContext(ctx, l, func(*Results, *http.Request) error {
+			close(started)
+			time.Sleep(50 * time.Millisecond)
+			atomic.StoreInt32(&finished, 1)
+			return nil
+		})
+	}()
+
+	go func() {
+		resp, err := http.Post("http://"+l.Addr().String(), "application/json", bytes.NewBufferString(`{"answers":[]}`))
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-started
+	cancel()
+	assert.Nil(t, <-errCh)
+	// the handler must have finished before serveContext returned
+	assert.EqualValues(t, 1, atomic.LoadInt32(&finished))
+}
+
+func TestListenAndServeContextTimeout(t *T) {
+	defer func(d time.Duration) { ShutdownTimeout = d }(ShutdownTimeout)
+	ShutdownTimeout = 10 * time.Millisecond
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	errCh := make(chan error)
+	go func() {
+		errCh <- serveContext(ctx, l, func(*Results, *http.Request) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+
+	go func() {
+		resp, err := http.Post("http://"+l.Addr().String(), "application/json", bytes.NewBufferString(`{"answers":[]}`))
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-started
+	cancel()
+	assert.Equal(t, context.DeadlineExceeded, <-errCh)
 }
 
 func TestAnswersSort(t *T) {
@@ -561,6 +1069,13 @@ func TestAnswerString(t *T) {
 	}
 	assert.Equal(t, "5", a.String())
 
+	a = &ResultsAnswer{
+		Value: &RatingValue{
+			Amount: 4,
+		},
+	}
+	assert.Equal(t, "4", a.String())
+
 	b := BooleanValue(true)
 	a = &ResultsAnswer{
 		Value: &b,
@@ -578,6 +1093,23 @@ func TestAnswerString(t *T) {
 	}
 	assert.Equal(t, "hey", a.String())
 
+	ev := EmailValue("a@example.com")
+	a = &ResultsAnswer{
+		Value: &ev,
+	}
+	assert.Equal(t, "a@example.com", a.String())
+
+	uv := URLValue("http://example.com")
+	a = &ResultsAnswer{
+		Value: &uv,
+	}
+	assert.Equal(t, "http://example.com", a.String())
+
+	a = &ResultsAnswer{
+		Value: &DateValue{time.Date(2016, 5, 20, 0, 0, 0, 0, time.UTC)},
+	}
+	assert.Equal(t, "2016-05-20", a.String())
+
 	a = &ResultsAnswer{
 		Value: &ChoiceValue{
 			Label:      "val",
@@ -0,0 +1,79 @@
+package tyform
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// currencyExponents holds the currencies whose minor unit isn't 1/100th of
+// the major unit
+var currencyExponents = map[string]int{
+	"BIF": 0,
+	"CLP": 0,
+	"DJF": 0,
+	"GNF": 0,
+	"ISK": 0,
+	"JPY": 0,
+	"KMF": 0,
+	"KRW": 0,
+	"PYG": 0,
+	"RWF": 0,
+	"UGX": 0,
+	"VND": 0,
+	"VUV": 0,
+	"XAF": 0,
+	"XOF": 0,
+	"XPF": 0,
+	"BHD": 3,
+	"IQD": 3,
+	"JOD": 3,
+	"KWD": 3,
+	"LYD": 3,
+	"OMR": 3,
+	"TND": 3,
+}
+
+// CurrencyExponent returns the number of decimal places in the given ISO 4217
+// currency, which is 2 for most currencies and for unknown ones
+func CurrencyExponent(currency string) int {
+	if e, ok := currencyExponents[strings.ToUpper(currency)]; ok {
+		return e
+	}
+	return 2
+}
+
+// FormatAmount formats an amount in the currency's minor units, like cents,
+// as a decimal string in the currency's major units, like "10.50"
+func FormatAmount(minor int64, currency string) string {
+	e := CurrencyExponent(currency)
+	return new(big.Rat).SetFrac64(minor, pow10(e)).FloatString(e)
+}
+
+// ParseAmount parses a decimal amount in the currency's major units, like
+// "10.50" or "1.05e+01", into the currency's minor units, like cents. Floats
+// are never used so there is no loss of precision. An error is returned if the
+// amount has more decimal places than the currency allows.
+func ParseAmount(s string, currency string) (int64, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	r.Mul(r, new(big.Rat).SetInt64(pow10(CurrencyExponent(currency))))
+	if !r.IsInt() {
+		return 0, fmt.Errorf("amount %q has too many decimal places for %s", s, currency)
+	}
+	if !r.Num().IsInt64() {
+		return 0, errors.New("amount is too large")
+	}
+	return r.Num().Int64(), nil
+}
+
+func pow10(e int) int64 {
+	p := int64(1)
+	for i := 0; i < e; i++ {
+		p *= 10
+	}
+	return p
+}
@@ -0,0 +1,55 @@
+package tyform
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	. "testing"
+)
+
+func TestCurrencyExponent(t *T) {
+	assert.Equal(t, 2, CurrencyExponent("USD"))
+	assert.Equal(t, 2, CurrencyExponent("eur"))
+	assert.Equal(t, 0, CurrencyExponent("JPY"))
+	assert.Equal(t, 3, CurrencyExponent("KWD"))
+	assert.Equal(t, 2, CurrencyExponent(""))
+}
+
+func TestFormatAmount(t *T) {
+	assert.Equal(t, "10.50", FormatAmount(1050, "USD"))
+	assert.Equal(t, "0.05", FormatAmount(5, "EUR"))
+	assert.Equal(t, "-0.05", FormatAmount(-5, "EUR"))
+	assert.Equal(t, "1050", FormatAmount(1050, "JPY"))
+	assert.Equal(t, "1.050", FormatAmount(1050, "KWD"))
+}
+
+func TestParseAmount(t *T) {
+	a, err := ParseAmount("10.50", "USD")
+	require.Nil(t, err)
+	assert.EqualValues(t, 1050, a)
+
+	a, err = ParseAmount("10", "USD")
+	require.Nil(t, err)
+	assert.EqualValues(t, 1000, a)
+
+	// exponent form that can't be represented exactly as a float
+	a, err = ParseAmount("1.111111e+06", "USD")
+	require.Nil(t, err)
+	assert.EqualValues(t, 111111100, a)
+
+	a, err = ParseAmount("0.29", "USD")
+	require.Nil(t, err)
+	assert.EqualValues(t, 29, a)
+
+	a, err = ParseAmount("1050", "JPY")
+	require.Nil(t, err)
+	assert.EqualValues(t, 1050, a)
+
+	_, err = ParseAmount("10.505", "USD")
+	assert.NotNil(t, err)
+	_, err = ParseAmount("10.5", "JPY")
+	assert.NotNil(t, err)
+	_, err = ParseAmount("ten", "USD")
+	assert.NotNil(t, err)
+	_, err = ParseAmount("1e+30", "USD")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,18 @@
+package tyform
+
+// DesignColors are the colors used by a Design. Each color is a hex string
+// like "#4FB0AE".
+type DesignColors struct {
+	Question   string `json:"question,omitempty"     bson:"q,omitempty"    validate:"validateColor"`
+	Answer     string `json:"answer,omitempty"       bson:"a,omitempty"    validate:"validateColor"`
+	Button     string `json:"button,omitempty"       bson:"b,omitempty"    validate:"validateColor"`
+	Background string `json:"background,omitempty"   bson:"bg,omitempty"   validate:"validateColor"`
+}
+
+// Design represents the look of a Form and can be sent to TypeForm's
+// [/designs](http://docs.typeform.io/docs/designs) endpoint. The ID of the
+// created design is then used as a Form's DesignID.
+type Design struct {
+	Colors DesignColors `json:"colors"                 bson:"c"`
+	Font   string       `json:"font,omitempty"         bson:"f,omitempty"    validate:"max=64"`
+}
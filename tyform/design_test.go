@@ -0,0 +1,70 @@
+package tyform
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/validator.v2"
+	. "testing"
+)
+
+func TestDesign(t *T) {
+	// colors must be hex
+	assert.NotNil(t, validator.Validate(&Design{
+		Colors: DesignColors{
+			Question: "red",
+		},
+	}))
+
+	// cannot have a font of > 64
+	assert.NotNil(t, validator.Validate(&Design{
+		Font: string(make([]byte, 65)),
+	}))
+
+	assert.Nil(t, validator.Validate(&Design{
+		Colors: DesignColors{
+			Question:   "#3D3D3D",
+			Answer:     "#4FB0AE",
+			Button:     "#4FB0AE",
+			Background: "#FFFFFF",
+		},
+		Font: "Vollkorn",
+	}))
+}
+
+func TestJSONDesign(t *T) {
+	d := &Design{
+		Colors: DesignColors{
+			Question:   "#3D3D3D",
+			Background: "#FFFFFF",
+		},
+		Font: "Vollkorn",
+	}
+	ds := `{"colors":{"question":"#3D3D3D","background":"#FFFFFF"},"font":"Vollkorn"}`
+	j, err := json.Marshal(d)
+	require.Nil(t, err)
+	assert.Equal(t, ds, string(j))
+
+	nd := &Design{}
+	err = json.Unmarshal(j, nd)
+	require.Nil(t, err)
+	assert.EqualValues(t, d, nd)
+}
+
+func TestBSONDesign(t *T) {
+	d := &Design{
+		Colors: DesignColors{
+			Question:   "#3D3D3D",
+			Background: "#FFFFFF",
+		},
+		Font: "Vollkorn",
+	}
+	j, err := bson.Marshal(d)
+	require.Nil(t, err)
+
+	nd := &Design{}
+	err = bson.Unmarshal(j, nd)
+	require.Nil(t, err)
+	assert.EqualValues(t, d, nd)
+}
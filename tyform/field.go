@@ -4,19 +4,42 @@
 // section of their api documentation.
 package tyform
 
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
 // Field is a generic Field that holds common properties of all Fields in a Form
 type Field struct {
-	Type        FieldType `json:"type"                  bson:"t"`
-	Question    string    `json:"question"              bson:"q"              validate:"nonzero,max=512"`
-	Ref         string    `json:"ref,omitempty"         bson:"r,omitempty"    validate:"max=128"`
-	Description string    `json:"description,omitempty" bson:"d"              validate:"max=512"`
-	Required    bool      `json:"required,omitempty"    bson:"req,omitempty"`
-	Tags        []string  `json:"tags,omitempty"        bson:"g,omitempty"    validate:"arrMap=min=1,arrMap=max=128,max=100"`
+	Type        FieldType   `json:"type"                  bson:"t"`
+	Question    string      `json:"question"              bson:"q"              validate:"nonzero,max=512"`
+	Ref         string      `json:"ref,omitempty"         bson:"r,omitempty"    validate:"max=128"`
+	Description string      `json:"description,omitempty" bson:"d"              validate:"max=512"`
+	Required    bool        `json:"required,omitempty"    bson:"req,omitempty"`
+	Tags        []string    `json:"tags,omitempty"        bson:"g,omitempty"    validate:"arrMap=min=1,arrMap=max=128,max=100"`
+	Attachment  *Attachment `json:"attachment,omitempty"  bson:"att,omitempty"`
 
 	// Value is only included so you can pair up a user's answer with the original field
 	Value interface{} `json:"value,omitempty"           bson:"-"`
 }
 
+// AttachmentType describes the type of an Attachment
+type AttachmentType string
+
+var (
+	AttachmentImage AttachmentType = "image"
+)
+
+// Attachment is media that's shown along with a Field. Images must first be
+// uploaded to typeform and then referenced by their ID.
+type Attachment struct {
+	Type    AttachmentType `json:"type"                  bson:"t"              validate:"validateAttachmentType"`
+	ImageID string         `json:"image_id"              bson:"i"              validate:"nonzero,max=128"`
+}
+
 // OpinionLabels represents a OpinionScale's labels property
 // It contains a label for the left, center, and right sides of the scale
 type OpinionLabels struct {
@@ -33,9 +56,42 @@ type OpinionScale struct {
 	Labels     OpinionLabels `json:"labels,omitempty"       bson:"l,omitempty"`
 }
 
-// MultipleChoiceChoice is a choice in a MultipleChoice's Choices slice
+// RatingShape is the shape that's shown for each step of a Rating
+type RatingShape string
+
+var (
+	RatingStar        RatingShape = "star"
+	RatingHeart       RatingShape = "heart"
+	RatingUser        RatingShape = "user"
+	RatingThumbsUp    RatingShape = "up"
+	RatingCrown       RatingShape = "crown"
+	RatingCat         RatingShape = "cat"
+	RatingDog         RatingShape = "dog"
+	RatingCircle      RatingShape = "circle"
+	RatingFlag        RatingShape = "flag"
+	RatingDroplet     RatingShape = "droplet"
+	RatingTick        RatingShape = "tick"
+	RatingLightbulb   RatingShape = "lightbulb"
+	RatingTrophy      RatingShape = "trophy"
+	RatingCloud       RatingShape = "cloud"
+	RatingThunderbolt RatingShape = "thunderbolt"
+	RatingPencil      RatingShape = "pencil"
+	RatingSkull       RatingShape = "skull"
+)
+
+// Rating is a scale from 1-steps shown using a shape. If Shape is empty then
+// typeform uses stars.
+type Rating struct {
+	Field `bson:",inline"`
+	Steps int64       `json:"steps"                  bson:"s"              validate:"min=3,max=10"`
+	Shape RatingShape `json:"shape,omitempty"        bson:"sh,omitempty"   validate:"validateRatingShape"`
+}
+
+// MultipleChoiceChoice is a choice in a MultipleChoice's Choices slice.
+// ImageID optionally references an image that was uploaded to typeform.
 type MultipleChoiceChoice struct {
-	Label string `json:"label"                          bson:"l"              validate:"nonzero, max=512"`
+	Label   string `json:"label"                        bson:"l"              validate:"nonzero, max=512"`
+	ImageID string `json:"image_id,omitempty"           bson:"i,omitempty"    validate:"max=128"`
 }
 
 // MultipleChoice is a question that contains multiple choices
@@ -44,6 +100,61 @@ type MultipleChoice struct {
 	Choices []MultipleChoiceChoice `json:"choices"      bson:"c"              validate:"min=1,max=25"`
 }
 
+// PictureChoiceChoice is a choice in a PictureChoice's Choices slice. ImageID
+// references an image that was uploaded to typeform.
+type PictureChoiceChoice struct {
+	Label   string `json:"label"                        bson:"l"              validate:"nonzero,max=512"`
+	ImageID string `json:"image_id"                     bson:"i"              validate:"nonzero,max=128"`
+}
+
+// PictureChoice is a question whose choices are shown as pictures
+type PictureChoice struct {
+	Field                  `bson:",inline"`
+	Choices                []PictureChoiceChoice `json:"choices"                            bson:"c"              validate:"min=1,max=25"`
+	AllowMultipleSelection bool                  `json:"allow_multiple_selection,omitempty" bson:"m,omitempty"`
+	Supersize              bool                  `json:"supersize,omitempty"                bson:"ss,omitempty"`
+	AddOtherChoice         bool                  `json:"add_other_choice,omitempty"         bson:"o,omitempty"`
+}
+
+// DropdownChoice is a choice in a Dropdown's Choices slice
+type DropdownChoice struct {
+	Label string `json:"label"                          bson:"l"              validate:"nonzero,max=512"`
+}
+
+// Dropdown is a question with a large list of choices that are shown in a
+// dropdown. If AlphabeticalOrder is set the choices are sorted by typeform.
+type Dropdown struct {
+	Field             `bson:",inline"`
+	Choices           []DropdownChoice `json:"choices"                      bson:"c"              validate:"min=1,max=1000"`
+	AlphabeticalOrder bool             `json:"alphabetical_order,omitempty" bson:"ao,omitempty"`
+}
+
+// SetChoices replaces the Dropdown's choices with one for each of the labels
+func (d *Dropdown) SetChoices(labels []string) {
+	d.Choices = make([]DropdownChoice, len(labels))
+	for i, l := range labels {
+		d.Choices[i] = DropdownChoice{l}
+	}
+}
+
+// ReadChoices replaces the Dropdown's choices with one for each line read from
+// r. Surrounding whitespace is trimmed from each line and empty lines are
+// skipped.
+func (d *Dropdown) ReadChoices(r io.Reader) error {
+	var labels []string
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		if l := strings.TrimSpace(s.Text()); l != "" {
+			labels = append(labels, l)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return err
+	}
+	d.SetChoices(labels)
+	return nil
+}
+
 // Statement is just text, no question to answer
 type Statement struct {
 	Field      `bson:",inline"`
@@ -56,6 +167,118 @@ type YesNo struct {
 	Field `bson:",inline"`
 }
 
+// Legal is a terms acceptance question that can be accepted or declined
+type Legal struct {
+	Field `bson:",inline"`
+}
+
+// FileUpload is a question whose answer is a file uploaded by the user
+type FileUpload struct {
+	Field `bson:",inline"`
+}
+
+// Payment is a question that takes a payment. Price is in the currency's
+// minor units, like cents, so it's precise. In json it's sent as a decimal in
+// the currency's major units.
+type Payment struct {
+	Field            `bson:",inline"`
+	Currency         string `json:"currency"                    bson:"cur"            validate:"regexp=^[A-Z]{3}$"`
+	Price            int64  `json:"-"                           bson:"p"              validate:"min=0"`
+	PriceDescription string `json:"price_description,omitempty" bson:"pd,omitempty"   validate:"max=512"`
+	ShowButton       bool   `json:"show_button,omitempty"       bson:"sb,omitempty"`
+}
+
+// paymentAlias has all of Payment's fields but not its methods
+type paymentAlias Payment
+
+// jsonPayment is used to marshal a Payment with its Price as a decimal
+type jsonPayment struct {
+	*paymentAlias
+	Price json.Number `json:"price"`
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (p Payment) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonPayment{
+		paymentAlias: (*paymentAlias)(&p),
+		Price:        json.Number(FormatAmount(p.Price, p.Currency)),
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (p *Payment) UnmarshalJSON(b []byte) error {
+	jp := jsonPayment{paymentAlias: (*paymentAlias)(p)}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(&jp); err != nil {
+		return err
+	}
+	p.Price = 0
+	if jp.Price == "" {
+		return nil
+	}
+	var err error
+	p.Price, err = ParseAmount(string(jp.Price), p.Currency)
+	return err
+}
+
+// ShortText is a question with a single line text answer. A MaxCharacters of 0
+// means there is no limit.
+type ShortText struct {
+	Field         `bson:",inline"`
+	MaxCharacters int64 `json:"max_characters,omitempty" bson:"mc,omitempty" validate:"min=0,max=256"`
+}
+
+// LongText is a question with a multiple line text answer. A MaxCharacters of
+// 0 means there is no limit.
+type LongText struct {
+	Field         `bson:",inline"`
+	MaxCharacters int64 `json:"max_characters,omitempty" bson:"mc,omitempty" validate:"min=0,max=8000"`
+}
+
+// Email is a question whose answer must be an email address
+type Email struct {
+	Field `bson:",inline"`
+}
+
+// Website is a question whose answer must be a url
+type Website struct {
+	Field `bson:",inline"`
+}
+
+// NumberRange is the range of a Number's answer. MinValue and MaxValue are
+// optional and inclusive, and if both are set MinValue cannot be greater than
+// MaxValue.
+type NumberRange struct {
+	MinValue *int64 `json:"min_value,omitempty"      bson:"mi,omitempty"`
+	MaxValue *int64 `json:"max_value,omitempty"      bson:"ma,omitempty"`
+}
+
+// Number is a question whose answer must be a whole number
+type Number struct {
+	Field       `bson:",inline"`
+	NumberRange `bson:",inline"                       validate:"validateNumberRange"`
+}
+
+// Date formats and separators for Date
+var (
+	DateFormatMMDDYYYY = "MMDDYYYY"
+	DateFormatDDMMYYYY = "DDMMYYYY"
+	DateFormatYYYYMMDD = "YYYYMMDD"
+
+	DateSeparatorSlash  = "/"
+	DateSeparatorDash   = "-"
+	DateSeparatorPeriod = "."
+)
+
+// Date is a question whose answer is a date. DateFormat and Separator describe
+// how the date is entered and default to MMDDYYYY and "/".
+type Date struct {
+	Field      `bson:",inline"`
+	DateFormat string `json:"date_format,omitempty"    bson:"df,omitempty"   validate:"regexp=^(MMDDYYYY|DDMMYYYY|YYYYMMDD)?$"`
+	Separator  string `json:"separator,omitempty"      bson:"sep,omitempty"  validate:"regexp=^(/|-|\\.)?$"`
+}
+
 // FieldType describes the type of field
 type FieldType string
 
@@ -64,24 +287,32 @@ var (
 	TypeOpinionScale   FieldType = "opinion_scale"
 	TypeMultipleChoice FieldType = "multiple_choice"
 	TypeYesNo          FieldType = "yes_no"
+	TypeShortText      FieldType = "short_text"
+	TypeLongText       FieldType = "long_text"
+	TypeEmail          FieldType = "email"
+	TypeWebsite        FieldType = "website"
+	TypeNumber         FieldType = "number"
+	TypeRating         FieldType = "rating"
+	TypeDropdown       FieldType = "dropdown"
+	TypePictureChoice  FieldType = "picture_choice"
+	TypeDate           FieldType = "date"
+	TypeLegal          FieldType = "legal"
+	TypeFileUpload     FieldType = "file_upload"
+	TypePayment        FieldType = "payment"
+	TypeGroup          FieldType = "group"
 )
 
 // emptyInterface can be used to get an empty specific struct for the type of
-// field that the field is. This is used by the Form to unmarshal
-func (f *Field) emptyInterface() (dst FieldInterface) {
-	switch f.Type {
-	case TypeStatement:
-		dst = &Statement{}
-	case TypeOpinionScale:
-		dst = &OpinionScale{}
-	case TypeMultipleChoice:
-		dst = &MultipleChoice{}
-	case TypeYesNo:
-		dst = &YesNo{}
-	default:
-		dst = f
+// field that the field is, using the types registered with RegisterFieldType.
+// Unregistered types get an UnknownField. This is used by the Form to unmarshal
+func (f *Field) emptyInterface() FieldInterface {
+	fieldTypesL.RLock()
+	fn, ok := fieldTypes[f.Type]
+	fieldTypesL.RUnlock()
+	if !ok {
+		return &UnknownField{}
 	}
-	return
+	return fn()
 }
 
 // FieldInterface can be used to get common properties off of the various types
@@ -93,6 +324,7 @@ type FieldInterface interface {
 	GetDescription() string
 	GetRequired() bool
 	GetTags() []string
+	GetAttachment() *Attachment
 	GetValue() interface{}
 	SetValue(v interface{})
 }
@@ -118,6 +350,9 @@ func (f *Field) GetRequired() bool {
 func (f *Field) GetTags() []string {
 	return f.Tags
 }
+func (f *Field) GetAttachment() *Attachment {
+	return f.Attachment
+}
 func (f *Field) GetValue() interface{} {
 	return f.Value
 }
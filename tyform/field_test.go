@@ -5,6 +5,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/validator.v2"
+	"strings"
 	. "testing"
 )
 
@@ -18,7 +19,7 @@ func randField(t FieldType) Field {
 func randChoices(l int) []MultipleChoiceChoice {
 	d := make([]MultipleChoiceChoice, l)
 	for i := range d {
-		d[i] = MultipleChoiceChoice{testutil.RandStr()}
+		d[i] = MultipleChoiceChoice{Label: testutil.RandStr()}
 	}
 	return d
 }
@@ -26,19 +27,52 @@ func randChoices(l int) []MultipleChoiceChoice {
 func TestMultipleChoiceChoice(t *T) {
 	// cannot have more than 512 characters
 	assert.NotNil(t, validator.Validate(&MultipleChoiceChoice{
-		string(make([]byte, 513)),
+		Label: string(make([]byte, 513)),
 	}))
 
 	// cannot be empty
 	assert.NotNil(t, validator.Validate(&MultipleChoiceChoice{
-		"",
+		Label: "",
 	}))
 
 	assert.Nil(t, validator.Validate(&MultipleChoiceChoice{
-		testutil.RandStr(),
+		Label: testutil.RandStr(),
+	}))
+
+	// the image id is optional but cannot be > 128
+	assert.NotNil(t, validator.Validate(&MultipleChoiceChoice{
+		Label:   testutil.RandStr(),
+		ImageID: string(make([]byte, 129)),
+	}))
+	assert.Nil(t, validator.Validate(&MultipleChoiceChoice{
+		Label:   testutil.RandStr(),
+		ImageID: testutil.RandStr(),
 	}))
 }
 
+func TestNumber(t *T) {
+	min, max := int64(10), int64(1)
+	n := &Number{
+		Field: randField(TypeNumber),
+		NumberRange: NumberRange{
+			MinValue: &min,
+			MaxValue: &max,
+		},
+	}
+	// min cannot be greater than max
+	assert.NotNil(t, validator.Validate(n))
+
+	max = 10
+	assert.Nil(t, validator.Validate(n))
+
+	// either can be set without the other
+	n.MaxValue = nil
+	assert.Nil(t, validator.Validate(n))
+	n.MinValue = nil
+	n.MaxValue = &max
+	assert.Nil(t, validator.Validate(n))
+}
+
 func TestMultipleChoice(t *T) {
 	// there is at least 1 choice required
 	assert.NotNil(t, validator.Validate(&MultipleChoice{
@@ -58,6 +92,113 @@ func TestMultipleChoice(t *T) {
 	}))
 }
 
+func TestAttachment(t *T) {
+	// must have an image id
+	assert.NotNil(t, validator.Validate(&Attachment{
+		Type: AttachmentImage,
+	}))
+
+	// cannot have an image id of > 128
+	assert.NotNil(t, validator.Validate(&Attachment{
+		Type:    AttachmentImage,
+		ImageID: string(make([]byte, 129)),
+	}))
+
+	assert.Nil(t, validator.Validate(&Attachment{
+		Type:    AttachmentImage,
+		ImageID: testutil.RandStr(),
+	}))
+
+	// must have a known type
+	assert.NotNil(t, validator.Validate(&Attachment{
+		ImageID: testutil.RandStr(),
+	}))
+	assert.NotNil(t, validator.Validate(&Attachment{
+		Type:    AttachmentType("video"),
+		ImageID: testutil.RandStr(),
+	}))
+
+	// the attachment on a field is validated
+	f := randField(TypeStatement)
+	f.Attachment = &Attachment{Type: AttachmentImage}
+	assert.NotNil(t, validator.Validate(&Statement{Field: f}))
+
+	f.Attachment = nil
+	assert.Nil(t, validator.Validate(&Statement{Field: f}))
+}
+
+func randPictureChoices(l int) []PictureChoiceChoice {
+	d := make([]PictureChoiceChoice, l)
+	for i := range d {
+		d[i] = PictureChoiceChoice{
+			Label:   testutil.RandStr(),
+			ImageID: testutil.RandStr(),
+		}
+	}
+	return d
+}
+
+func TestPictureChoice(t *T) {
+	// there is at least 1 choice required
+	assert.NotNil(t, validator.Validate(&PictureChoice{
+		Field:   randField(TypePictureChoice),
+		Choices: []PictureChoiceChoice{},
+	}))
+
+	// you cannot have more than 25 choices
+	assert.NotNil(t, validator.Validate(&PictureChoice{
+		Field:   randField(TypePictureChoice),
+		Choices: randPictureChoices(26),
+	}))
+
+	// each choice needs an image
+	assert.NotNil(t, validator.Validate(&PictureChoice{
+		Field: randField(TypePictureChoice),
+		Choices: []PictureChoiceChoice{
+			{Label: testutil.RandStr()},
+		},
+	}))
+
+	assert.Nil(t, validator.Validate(&PictureChoice{
+		Field:                  randField(TypePictureChoice),
+		Choices:                randPictureChoices(3),
+		AllowMultipleSelection: true,
+	}))
+}
+
+func randLabels(l int) []string {
+	d := make([]string, l)
+	for i := range d {
+		d[i] = testutil.RandStr()
+	}
+	return d
+}
+
+func TestDropdown(t *T) {
+	// there is at least 1 choice required
+	d := &Dropdown{Field: randField(TypeDropdown)}
+	assert.NotNil(t, validator.Validate(d))
+
+	// you cannot have more than 1000 choices
+	d.SetChoices(randLabels(1001))
+	assert.NotNil(t, validator.Validate(d))
+
+	// choices must have a label
+	d.SetChoices([]string{""})
+	assert.NotNil(t, validator.Validate(d))
+
+	d.SetChoices(randLabels(500))
+	assert.Nil(t, validator.Validate(d))
+	assert.Len(t, d.Choices, 500)
+}
+
+func TestDropdownReadChoices(t *T) {
+	d := &Dropdown{Field: randField(TypeDropdown)}
+	err := d.ReadChoices(strings.NewReader("France\n  Germany \n\nItaly\r\n"))
+	require.Nil(t, err)
+	assert.Equal(t, []DropdownChoice{{"France"}, {"Germany"}, {"Italy"}}, d.Choices)
+}
+
 func TestOpinionLabels(t *T) {
 	// cannot have a left of > 100
 	assert.NotNil(t, validator.Validate(&OpinionLabels{
@@ -93,6 +234,122 @@ func TestOpinionScale(t *T) {
 	}))
 }
 
+func TestRating(t *T) {
+	// steps must be >= 3
+	assert.NotNil(t, validator.Validate(&Rating{
+		Field: randField(TypeRating),
+		Steps: 2,
+	}))
+
+	// steps must be <= 10
+	assert.NotNil(t, validator.Validate(&Rating{
+		Field: randField(TypeRating),
+		Steps: 11,
+	}))
+
+	// shape must be known
+	assert.NotNil(t, validator.Validate(&Rating{
+		Field: randField(TypeRating),
+		Steps: 5,
+		Shape: "square",
+	}))
+
+	assert.Nil(t, validator.Validate(&Rating{
+		Field: randField(TypeRating),
+		Steps: 5,
+	}))
+	assert.Nil(t, validator.Validate(&Rating{
+		Field: randField(TypeRating),
+		Steps: 10,
+		Shape: RatingHeart,
+	}))
+}
+
+func TestDate(t *T) {
+	// date format must be known
+	assert.NotNil(t, validator.Validate(&Date{
+		Field:      randField(TypeDate),
+		DateFormat: "YYMMDD",
+	}))
+
+	// separator must be known
+	assert.NotNil(t, validator.Validate(&Date{
+		Field:     randField(TypeDate),
+		Separator: "_",
+	}))
+
+	assert.Nil(t, validator.Validate(&Date{
+		Field: randField(TypeDate),
+	}))
+	assert.Nil(t, validator.Validate(&Date{
+		Field:      randField(TypeDate),
+		DateFormat: DateFormatDDMMYYYY,
+		Separator:  DateSeparatorPeriod,
+	}))
+}
+
+func TestPayment(t *T) {
+	// currency must be an ISO 4217 code
+	assert.NotNil(t, validator.Validate(&Payment{
+		Field:    randField(TypePayment),
+		Currency: "usd",
+	}))
+
+	// price cannot be negative
+	assert.NotNil(t, validator.Validate(&Payment{
+		Field:    randField(TypePayment),
+		Currency: "USD",
+		Price:    -1,
+	}))
+
+	assert.Nil(t, validator.Validate(&Payment{
+		Field:    randField(TypePayment),
+		Currency: "USD",
+		Price:    1050,
+	}))
+}
+
+func TestShortText(t *T) {
+	// max characters must be >= 0
+	assert.NotNil(t, validator.Validate(&ShortText{
+		Field:         randField(TypeShortText),
+		MaxCharacters: -1,
+	}))
+
+	// max characters must be <= 256
+	assert.NotNil(t, validator.Validate(&ShortText{
+		Field:         randField(TypeShortText),
+		MaxCharacters: 257,
+	}))
+
+	assert.Nil(t, validator.Validate(&ShortText{
+		Field: randField(TypeShortText),
+	}))
+	assert.Nil(t, validator.Validate(&ShortText{
+		Field:         randField(TypeShortText),
+		MaxCharacters: 100,
+	}))
+}
+
+func TestLongText(t *T) {
+	// max characters must be >= 0
+	assert.NotNil(t, validator.Validate(&LongText{
+		Field:         randField(TypeLongText),
+		MaxCharacters: -1,
+	}))
+
+	// max characters must be <= 8000
+	assert.NotNil(t, validator.Validate(&LongText{
+		Field:         randField(TypeLongText),
+		MaxCharacters: 8001,
+	}))
+
+	assert.Nil(t, validator.Validate(&LongText{
+		Field:         randField(TypeLongText),
+		MaxCharacters: 1000,
+	}))
+}
+
 func TestFieldInterface(t *T) {
 	s := interface{}(&Statement{})
 	_, ok := s.(FieldInterface)
@@ -106,7 +363,56 @@ func TestFieldInterface(t *T) {
 	_, ok = os.(FieldInterface)
 	assert.True(t, ok)
 
+	st := interface{}(&ShortText{})
+	_, ok = st.(FieldInterface)
+	assert.True(t, ok)
+
+	lt := interface{}(&LongText{})
+	_, ok = lt.(FieldInterface)
+	assert.True(t, ok)
+
+	e := interface{}(&Email{})
+	_, ok = e.(FieldInterface)
+	assert.True(t, ok)
+
+	w := interface{}(&Website{})
+	_, ok = w.(FieldInterface)
+	assert.True(t, ok)
+
+	n := interface{}(&Number{})
+	_, ok = n.(FieldInterface)
+	assert.True(t, ok)
+
+	r := interface{}(&Rating{})
+	_, ok = r.(FieldInterface)
+	assert.True(t, ok)
+
+	d := interface{}(&Dropdown{})
+	_, ok = d.(FieldInterface)
+	assert.True(t, ok)
+
+	pc := interface{}(&PictureChoice{})
+	_, ok = pc.(FieldInterface)
+	assert.True(t, ok)
+
+	dt := interface{}(&Date{})
+	_, ok = dt.(FieldInterface)
+	assert.True(t, ok)
+
+	l := interface{}(&Legal{})
+	_, ok = l.(FieldInterface)
+	assert.True(t, ok)
+
+	fu := interface{}(&FileUpload{})
+	_, ok = fu.(FieldInterface)
+	assert.True(t, ok)
+
+	p := interface{}(&Payment{})
+	_, ok = p.(FieldInterface)
+	assert.True(t, ok)
+
 	tags := []string{"tag"}
+	att := &Attachment{Type: AttachmentImage, ImageID: "img"}
 	f := &Field{
 		Type:        TypeStatement,
 		Question:    "Hey?",
@@ -114,6 +420,7 @@ func TestFieldInterface(t *T) {
 		Description: "This is a test",
 		Required:    true,
 		Tags:        tags,
+		Attachment:  att,
 	}
 	fi, ok := interface{}(f).(FieldInterface)
 	require.True(t, ok)
@@ -124,6 +431,7 @@ func TestFieldInterface(t *T) {
 	assert.Equal(t, "This is a test", fi.GetDescription())
 	assert.Equal(t, true, fi.GetRequired())
 	assert.Equal(t, tags, f.GetTags())
+	assert.Equal(t, att, fi.GetAttachment())
 	fi.SetValue("val")
 	assert.Equal(t, "val", f.Value)
 	assert.Equal(t, "val", f.GetValue())
@@ -11,25 +11,42 @@ type FormMetadata struct {
 	Tags       []string `json:"tags,omitempty"               bson:"g,omitempty" validate:"arrMap=min=1,arrMap=max=128,max=100"`
 	WebhookURL string   `json:"webhook_submit_url,omitempty" bson:"w,omitempty" validate:"validateURL"`
 	DesignID   string   `json:"design_id,omitempty"          bson:"d,omitempty" validate:"max=128"`
+
+	// Hidden are the names of the hidden fields that can be passed to the
+	// form in its url, like ?user_id=1. Their values are included in the
+	// Results.
+	Hidden []string `json:"hidden,omitempty"             bson:"h,omitempty" validate:"max=100,validateHiddenFields"`
 }
 
 // A Form is a group of Fields that can be submitted to TypeForm's [/forms](http://docs.typeform.io/docs/forms)
 // endpoint
 type Form struct {
 	FormMetadata `bson:",inline"`
-	Fields       []FieldInterface `json:"fields"             bson:"f"           validate:"min=1,max=500"`
+	Fields       []FieldInterface `json:"fields"                bson:"f"            validate:"min=1,max=500"`
+	LogicJumps   []LogicJump      `json:"logic_jumps,omitempty" bson:"lj,omitempty" validate:"max=500"`
+
+	WelcomeScreen   *WelcomeScreen   `json:"welcome_screen,omitempty"   bson:"ws,omitempty"`
+	ThankYouScreens []ThankYouScreen `json:"thankyou_screens,omitempty" bson:"ty,omitempty" validate:"max=50"`
 }
 
 // jsonForm is used to Unmarshal into since it has Fields of json.RawMessage
 type jsonForm struct {
 	FormMetadata
-	Fields []json.RawMessage `json:"fields"                  bson:"f"`
+	Fields     []json.RawMessage `json:"fields"                  bson:"f"`
+	LogicJumps []LogicJump       `json:"logic_jumps,omitempty"`
+
+	WelcomeScreen   *WelcomeScreen   `json:"welcome_screen,omitempty"`
+	ThankYouScreens []ThankYouScreen `json:"thankyou_screens,omitempty"`
 }
 
 // bsonForm is used to Unmarshal into since it has Fields of bson.Raw
 type bsonForm struct {
 	FormMetadata `bson:",inline"`
-	Fields       []bson.Raw `json:"fields"                   bson:"f"`
+	Fields       []bson.Raw  `json:"fields"                   bson:"f"`
+	LogicJumps   []LogicJump `json:"logic_jumps,omitempty"    bson:"lj,omitempty"`
+
+	WelcomeScreen   *WelcomeScreen   `bson:"ws,omitempty"`
+	ThankYouScreens []ThankYouScreen `bson:"ty,omitempty"`
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface
@@ -39,11 +56,38 @@ func (f *Form) UnmarshalJSON(b []byte) error {
 		return err
 	}
 	f.FormMetadata = jf.FormMetadata
+	f.LogicJumps = jf.LogicJumps
+	f.WelcomeScreen = jf.WelcomeScreen
+	f.ThankYouScreens = jf.ThankYouScreens
+
+	var err error
+	f.Fields, err = unmarshalJSONFields(jf.Fields)
+	return err
+}
+
+// SetBSON implements the bson.Setter interface
+func (f *Form) SetBSON(raw bson.Raw) error {
+	bf := &bsonForm{}
+	if err := raw.Unmarshal(bf); err != nil {
+		return err
+	}
+	f.FormMetadata = bf.FormMetadata
+	f.LogicJumps = bf.LogicJumps
+	f.WelcomeScreen = bf.WelcomeScreen
+	f.ThankYouScreens = bf.ThankYouScreens
+
+	var err error
+	f.Fields, err = unmarshalBSONFields(bf.Fields)
+	return err
+}
 
+// unmarshalJSONFields unmarshals each of the fields into the specific struct
+// for its type
+func unmarshalJSONFields(raw []json.RawMessage) ([]FieldInterface, error) {
 	var err error
 	var dst FieldInterface
-	f.Fields = make([]FieldInterface, len(jf.Fields))
-	for i, qstr := range jf.Fields {
+	fields := make([]FieldInterface, len(raw))
+	for i, qstr := range raw {
 		q := &Field{}
 		if err = json.Unmarshal(qstr, q); err != nil {
 			break
@@ -52,23 +96,18 @@ func (f *Form) UnmarshalJSON(b []byte) error {
 		if err = json.Unmarshal(qstr, dst); err != nil {
 			break
 		}
-		f.Fields[i] = dst
+		fields[i] = dst
 	}
-	return err
+	return fields, err
 }
 
-// SetBSON implements the bson.Setter interface
-func (f *Form) SetBSON(raw bson.Raw) error {
-	bf := &bsonForm{}
-	if err := raw.Unmarshal(bf); err != nil {
-		return err
-	}
-	f.FormMetadata = bf.FormMetadata
-
+// unmarshalBSONFields unmarshals each of the fields into the specific struct
+// for its type
+func unmarshalBSONFields(raw []bson.Raw) ([]FieldInterface, error) {
 	var err error
 	var dst FieldInterface
-	f.Fields = make([]FieldInterface, len(bf.Fields))
-	for i, qstr := range bf.Fields {
+	fields := make([]FieldInterface, len(raw))
+	for i, qstr := range raw {
 		q := &Field{}
 		if err = qstr.Unmarshal(q); err != nil {
 			break
@@ -77,7 +116,7 @@ func (f *Form) SetBSON(raw bson.Raw) error {
 		if err = qstr.Unmarshal(dst); err != nil {
 			break
 		}
-		f.Fields[i] = dst
+		fields[i] = dst
 	}
-	return err
+	return fields, err
 }
@@ -29,6 +29,42 @@ func TestJSONStatement(t *T) {
 	assert.EqualValues(t, f, nf)
 }
 
+func TestHiddenFields(t *T) {
+	f := &Form{
+		FormMetadata: FormMetadata{
+			Title:  "Hidden",
+			Hidden: []string{"user_id", "source"},
+		},
+		Fields: []FieldInterface{
+			&Statement{
+				Field: Field{
+					Type: TypeStatement,
+				},
+			},
+		},
+	}
+	fs := `{"title":"Hidden","hidden":["user_id","source"],"fields":[{"type":"statement","question":""}]}`
+	j, err := json.Marshal(f)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	nf := &Form{}
+	err = json.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+
+	j, err = bson.Marshal(f)
+	require.Nil(t, err)
+	m := bson.M{}
+	require.Nil(t, bson.Unmarshal(j, &m))
+	assert.Equal(t, []interface{}{"user_id", "source"}, m["h"])
+
+	nf = &Form{}
+	err = bson.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
 func TestBSONStatement(t *T) {
 	s := &Statement{
 		Field: Field{
@@ -58,6 +94,64 @@ func TestBSONStatement(t *T) {
 	assert.EqualValues(t, f, nf)
 }
 
+func TestJSONAttachment(t *T) {
+	f := &Form{
+		Fields: []FieldInterface{
+			&Statement{
+				Field: Field{
+					Type: TypeStatement,
+					Attachment: &Attachment{
+						Type:    AttachmentImage,
+						ImageID: "img",
+					},
+				},
+			},
+		},
+	}
+	fs := `{"title":"","fields":[{"type":"statement","question":"","attachment":{"type":"image","image_id":"img"}}]}`
+	j, err := json.Marshal(f)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	nf := &Form{}
+	err = json.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
+func TestBSONAttachment(t *T) {
+	s := &Statement{
+		Field: Field{
+			Type:     TypeStatement,
+			Question: "Hey?",
+			Attachment: &Attachment{
+				Type:    AttachmentImage,
+				ImageID: "img",
+			},
+		},
+	}
+	f := &Form{
+		Fields: []FieldInterface{s},
+	}
+	fexp := struct {
+		Title  string       `bson:"t"`
+		Fields []*Statement `bson:"f"`
+	}{
+		Title:  "",
+		Fields: []*Statement{s},
+	}
+	j, err := bson.Marshal(f)
+	require.Nil(t, err)
+	jexp, err := bson.Marshal(fexp)
+	require.Nil(t, err)
+	assert.Equal(t, string(jexp), string(j))
+
+	nf := &Form{}
+	err = bson.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
 func TestJSONMultipleChoice(t *T) {
 	f := &Form{
 		Fields: []FieldInterface{
@@ -84,6 +178,44 @@ func TestJSONMultipleChoice(t *T) {
 	assert.EqualValues(t, f, nf)
 }
 
+func TestMultipleChoiceImage(t *T) {
+	mc := &MultipleChoice{
+		Field: Field{
+			Type: TypeMultipleChoice,
+		},
+		Choices: []MultipleChoiceChoice{
+			MultipleChoiceChoice{
+				Label:   "Picture",
+				ImageID: "img",
+			},
+		},
+	}
+	f := &Form{
+		Fields: []FieldInterface{mc},
+	}
+	fs := `{"title":"","fields":[{"type":"multiple_choice","question":"","choices":[{"label":"Picture","image_id":"img"}]}]}`
+	j, err := json.Marshal(f)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	nf := &Form{}
+	err = json.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+
+	j, err = bson.Marshal(f)
+	require.Nil(t, err)
+	m := bson.M{}
+	require.Nil(t, bson.Unmarshal(j, &m))
+	c := m["f"].([]interface{})[0].(bson.M)["c"].([]interface{})[0]
+	assert.Equal(t, bson.M{"l": "Picture", "i": "img"}, c)
+
+	nf = &Form{}
+	err = bson.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
 func TestBSONMultipleChoice(t *T) {
 	mc := &MultipleChoice{
 		Field: Field{
@@ -179,3 +311,559 @@ func TestBSONOpinionLabels(t *T) {
 	require.Nil(t, err)
 	assert.EqualValues(t, f, nf)
 }
+
+func TestJSONShortText(t *T) {
+	f := &Form{
+		Fields: []FieldInterface{
+			&ShortText{
+				Field: Field{
+					Type: TypeShortText,
+				},
+				MaxCharacters: 50,
+			},
+			&LongText{
+				Field: Field{
+					Type: TypeLongText,
+				},
+				MaxCharacters: 500,
+			},
+		},
+	}
+	fs := `{"title":"","fields":[{"type":"short_text","question":"","max_characters":50},{"type":"long_text","question":"","max_characters":500}]}`
+	j, err := json.Marshal(f)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	nf := &Form{}
+	err = json.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
+func TestBSONShortText(t *T) {
+	st := &ShortText{
+		Field: Field{
+			Type: TypeShortText,
+		},
+		MaxCharacters: 50,
+	}
+	lt := &LongText{
+		Field: Field{
+			Type: TypeLongText,
+		},
+		MaxCharacters: 500,
+	}
+	f := &Form{
+		Fields: []FieldInterface{st, lt},
+	}
+	fexp := struct {
+		Title  string        `bson:"t"`
+		Fields []interface{} `bson:"f"`
+	}{
+		Title:  "",
+		Fields: []interface{}{st, lt},
+	}
+	j, err := bson.Marshal(f)
+	require.Nil(t, err)
+	jexp, err := bson.Marshal(fexp)
+	require.Nil(t, err)
+	assert.Equal(t, string(jexp), string(j))
+
+	nf := &Form{}
+	err = bson.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
+func TestJSONNumber(t *T) {
+	min, max := int64(0), int64(10)
+	f := &Form{
+		Fields: []FieldInterface{
+			&Email{
+				Field: Field{
+					Type: TypeEmail,
+				},
+			},
+			&Website{
+				Field: Field{
+					Type: TypeWebsite,
+				},
+			},
+			&Number{
+				Field: Field{
+					Type: TypeNumber,
+				},
+				NumberRange: NumberRange{
+					MinValue: &min,
+					MaxValue: &max,
+				},
+			},
+		},
+	}
+	fs := `{"title":"","fields":[{"type":"email","question":""},{"type":"website","question":""},{"type":"number","question":"","min_value":0,"max_value":10}]}`
+	j, err := json.Marshal(f)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	nf := &Form{}
+	err = json.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
+func TestBSONNumber(t *T) {
+	min := int64(0)
+	e := &Email{
+		Field: Field{
+			Type: TypeEmail,
+		},
+	}
+	w := &Website{
+		Field: Field{
+			Type: TypeWebsite,
+		},
+	}
+	n := &Number{
+		Field: Field{
+			Type: TypeNumber,
+		},
+		NumberRange: NumberRange{
+			MinValue: &min,
+		},
+	}
+	f := &Form{
+		Fields: []FieldInterface{e, w, n},
+	}
+	fexp := struct {
+		Title  string        `bson:"t"`
+		Fields []interface{} `bson:"f"`
+	}{
+		Title:  "",
+		Fields: []interface{}{e, w, n},
+	}
+	j, err := bson.Marshal(f)
+	require.Nil(t, err)
+	jexp, err := bson.Marshal(fexp)
+	require.Nil(t, err)
+	assert.Equal(t, string(jexp), string(j))
+
+	m := bson.M{}
+	require.Nil(t, bson.Unmarshal(j, &m))
+	assert.Equal(t, int64(0), m["f"].([]interface{})[2].(bson.M)["mi"])
+
+	nf := &Form{}
+	err = bson.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
+func TestJSONRating(t *T) {
+	f := &Form{
+		Fields: []FieldInterface{
+			&Rating{
+				Field: Field{
+					Type: TypeRating,
+				},
+				Steps: 5,
+				Shape: RatingThumbsUp,
+			},
+		},
+	}
+	fs := `{"title":"","fields":[{"type":"rating","question":"","steps":5,"shape":"up"}]}`
+	j, err := json.Marshal(f)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	nf := &Form{}
+	err = json.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
+func TestBSONRating(t *T) {
+	r := &Rating{
+		Field: Field{
+			Type: TypeRating,
+		},
+		Steps: 5,
+		Shape: RatingStar,
+	}
+	f := &Form{
+		Fields: []FieldInterface{r},
+	}
+	fexp := struct {
+		Title  string    `bson:"t"`
+		Fields []*Rating `bson:"f"`
+	}{
+		Title:  "",
+		Fields: []*Rating{r},
+	}
+	j, err := bson.Marshal(f)
+	require.Nil(t, err)
+	jexp, err := bson.Marshal(fexp)
+	require.Nil(t, err)
+	assert.Equal(t, string(jexp), string(j))
+
+	nf := &Form{}
+	err = bson.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
+func TestJSONDropdown(t *T) {
+	f := &Form{
+		Fields: []FieldInterface{
+			&Dropdown{
+				Field: Field{
+					Type: TypeDropdown,
+				},
+				Choices: []DropdownChoice{
+					DropdownChoice{
+						Label: "Label",
+					},
+				},
+				AlphabeticalOrder: true,
+			},
+		},
+	}
+	fs := `{"title":"","fields":[{"type":"dropdown","question":"","choices":[{"label":"Label"}],"alphabetical_order":true}]}`
+	j, err := json.Marshal(f)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	nf := &Form{}
+	err = json.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
+func TestBSONDropdown(t *T) {
+	d := &Dropdown{
+		Field: Field{
+			Type: TypeDropdown,
+		},
+		Choices: []DropdownChoice{
+			DropdownChoice{
+				Label: "Label",
+			},
+		},
+		AlphabeticalOrder: true,
+	}
+	f := &Form{
+		Fields: []FieldInterface{d},
+	}
+	fexp := struct {
+		Title  string      `bson:"t"`
+		Fields []*Dropdown `bson:"f"`
+	}{
+		Title:  "",
+		Fields: []*Dropdown{d},
+	}
+	j, err := bson.Marshal(f)
+	require.Nil(t, err)
+	jexp, err := bson.Marshal(fexp)
+	require.Nil(t, err)
+	assert.Equal(t, string(jexp), string(j))
+
+	nf := &Form{}
+	err = bson.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
+func TestJSONPictureChoice(t *T) {
+	f := &Form{
+		Fields: []FieldInterface{
+			&PictureChoice{
+				Field: Field{
+					Type: TypePictureChoice,
+				},
+				Choices: []PictureChoiceChoice{
+					PictureChoiceChoice{
+						Label:   "Label",
+						ImageID: "img",
+					},
+				},
+				AllowMultipleSelection: true,
+				Supersize:              true,
+				AddOtherChoice:         true,
+			},
+		},
+	}
+	fs := `{"title":"","fields":[{"type":"picture_choice","question":"","choices":[{"label":"Label","image_id":"img"}],"allow_multiple_selection":true,"supersize":true,"add_other_choice":true}]}`
+	j, err := json.Marshal(f)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	nf := &Form{}
+	err = json.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
+func TestBSONPictureChoice(t *T) {
+	pc := &PictureChoice{
+		Field: Field{
+			Type: TypePictureChoice,
+		},
+		Choices: []PictureChoiceChoice{
+			PictureChoiceChoice{
+				Label:   "Label",
+				ImageID: "img",
+			},
+		},
+		Supersize: true,
+	}
+	f := &Form{
+		Fields: []FieldInterface{pc},
+	}
+	fexp := struct {
+		Title  string           `bson:"t"`
+		Fields []*PictureChoice `bson:"f"`
+	}{
+		Title:  "",
+		Fields: []*PictureChoice{pc},
+	}
+	j, err := bson.Marshal(f)
+	require.Nil(t, err)
+	jexp, err := bson.Marshal(fexp)
+	require.Nil(t, err)
+	assert.Equal(t, string(jexp), string(j))
+
+	nf := &Form{}
+	err = bson.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
+func TestJSONDate(t *T) {
+	f := &Form{
+		Fields: []FieldInterface{
+			&Date{
+				Field: Field{
+					Type: TypeDate,
+				},
+				DateFormat: DateFormatYYYYMMDD,
+				Separator:  DateSeparatorDash,
+			},
+		},
+	}
+	fs := `{"title":"","fields":[{"type":"date","question":"","date_format":"YYYYMMDD","separator":"-"}]}`
+	j, err := json.Marshal(f)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	nf := &Form{}
+	err = json.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
+func TestBSONDate(t *T) {
+	d := &Date{
+		Field: Field{
+			Type: TypeDate,
+		},
+		DateFormat: DateFormatYYYYMMDD,
+		Separator:  DateSeparatorDash,
+	}
+	f := &Form{
+		Fields: []FieldInterface{d},
+	}
+	fexp := struct {
+		Title  string  `bson:"t"`
+		Fields []*Date `bson:"f"`
+	}{
+		Title:  "",
+		Fields: []*Date{d},
+	}
+	j, err := bson.Marshal(f)
+	require.Nil(t, err)
+	jexp, err := bson.Marshal(fexp)
+	require.Nil(t, err)
+	assert.Equal(t, string(jexp), string(j))
+
+	nf := &Form{}
+	err = bson.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
+func TestJSONLegal(t *T) {
+	f := &Form{
+		Fields: []FieldInterface{
+			&Legal{
+				Field: Field{
+					Type:     TypeLegal,
+					Required: true,
+				},
+			},
+			&YesNo{
+				Field: Field{
+					Type: TypeYesNo,
+				},
+			},
+		},
+	}
+	fs := `{"title":"","fields":[{"type":"legal","question":"","required":true},{"type":"yes_no","question":""}]}`
+	j, err := json.Marshal(f)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	nf := &Form{}
+	err = json.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
+func TestBSONLegal(t *T) {
+	l := &Legal{
+		Field: Field{
+			Type:     TypeLegal,
+			Required: true,
+		},
+	}
+	f := &Form{
+		Fields: []FieldInterface{l},
+	}
+	fexp := struct {
+		Title  string   `bson:"t"`
+		Fields []*Legal `bson:"f"`
+	}{
+		Title:  "",
+		Fields: []*Legal{l},
+	}
+	j, err := bson.Marshal(f)
+	require.Nil(t, err)
+	jexp, err := bson.Marshal(fexp)
+	require.Nil(t, err)
+	assert.Equal(t, string(jexp), string(j))
+
+	nf := &Form{}
+	err = bson.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
+func TestJSONFileUpload(t *T) {
+	f := &Form{
+		Fields: []FieldInterface{
+			&FileUpload{
+				Field: Field{
+					Type: TypeFileUpload,
+				},
+			},
+		},
+	}
+	fs := `{"title":"","fields":[{"type":"file_upload","question":""}]}`
+	j, err := json.Marshal(f)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	nf := &Form{}
+	err = json.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
+func TestBSONFileUpload(t *T) {
+	fu := &FileUpload{
+		Field: Field{
+			Type: TypeFileUpload,
+		},
+	}
+	f := &Form{
+		Fields: []FieldInterface{fu},
+	}
+	fexp := struct {
+		Title  string        `bson:"t"`
+		Fields []*FileUpload `bson:"f"`
+	}{
+		Title:  "",
+		Fields: []*FileUpload{fu},
+	}
+	j, err := bson.Marshal(f)
+	require.Nil(t, err)
+	jexp, err := bson.Marshal(fexp)
+	require.Nil(t, err)
+	assert.Equal(t, string(jexp), string(j))
+
+	nf := &Form{}
+	err = bson.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
+func TestJSONPayment(t *T) {
+	f := &Form{
+		Fields: []FieldInterface{
+			&Payment{
+				Field: Field{
+					Type: TypePayment,
+				},
+				Currency:         "USD",
+				Price:            1050,
+				PriceDescription: "Ticket",
+				ShowButton:       true,
+			},
+		},
+	}
+	fs := `{"title":"","fields":[{"type":"payment","question":"","currency":"USD","price_description":"Ticket","show_button":true,"price":10.50}]}`
+	j, err := json.Marshal(f)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	nf := &Form{}
+	err = json.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+
+	fs = `{"title":"","fields":[{"type":"payment","question":"","currency":"JPY","price":1.05e+03}]}`
+	nf = &Form{}
+	err = json.Unmarshal([]byte(fs), nf)
+	require.Nil(t, err)
+	require.Len(t, nf.Fields, 1)
+	p, ok := nf.Fields[0].(*Payment)
+	require.True(t, ok)
+	assert.EqualValues(t, 1050, p.Price)
+
+	fs = `{"title":"","fields":[{"type":"payment","question":"","currency":"USD","price":10.505}]}`
+	nf = &Form{}
+	err = json.Unmarshal([]byte(fs), nf)
+	assert.NotNil(t, err)
+
+	// a Payment that isn't a pointer keeps its price
+	j, err = json.Marshal(Payment{Field: Field{Type: TypePayment}, Currency: "USD", Price: 1050})
+	require.Nil(t, err)
+	assert.Equal(t, `{"type":"payment","question":"","currency":"USD","price":10.50}`, string(j))
+}
+
+func TestBSONPayment(t *T) {
+	p := &Payment{
+		Field: Field{
+			Type: TypePayment,
+		},
+		Currency: "USD",
+		Price:    1050,
+	}
+	f := &Form{
+		Fields: []FieldInterface{p},
+	}
+	fexp := struct {
+		Title  string     `bson:"t"`
+		Fields []*Payment `bson:"f"`
+	}{
+		Title:  "",
+		Fields: []*Payment{p},
+	}
+	j, err := bson.Marshal(f)
+	require.Nil(t, err)
+	jexp, err := bson.Marshal(fexp)
+	require.Nil(t, err)
+	assert.Equal(t, string(jexp), string(j))
+
+	nf := &Form{}
+	err = bson.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
@@ -0,0 +1,57 @@
+package tyform
+
+import (
+	"encoding/json"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Group is a statement with its own Fields that are shown underneath it. The
+// nested Fields are validated along with the Group and cannot be Groups
+// themselves.
+type Group struct {
+	Field      `bson:",inline"`
+	ButtonText string           `json:"button_text,omitempty" bson:"b,omitempty"    validate:"max=128"`
+	Fields     []FieldInterface `json:"fields"                bson:"f"              validate:"min=1,max=500,validateGroupFields"`
+}
+
+// jsonGroup is used to Unmarshal into since it has Fields of json.RawMessage
+type jsonGroup struct {
+	Field
+	ButtonText string            `json:"button_text,omitempty"`
+	Fields     []json.RawMessage `json:"fields"`
+}
+
+// bsonGroup is used to Unmarshal into since it has Fields of bson.Raw
+type bsonGroup struct {
+	Field      `bson:",inline"`
+	ButtonText string     `bson:"b,omitempty"`
+	Fields     []bson.Raw `bson:"f"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (g *Group) UnmarshalJSON(b []byte) error {
+	jg := &jsonGroup{}
+	if err := json.Unmarshal(b, jg); err != nil {
+		return err
+	}
+	g.Field = jg.Field
+	g.ButtonText = jg.ButtonText
+
+	var err error
+	g.Fields, err = unmarshalJSONFields(jg.Fields)
+	return err
+}
+
+// SetBSON implements the bson.Setter interface
+func (g *Group) SetBSON(raw bson.Raw) error {
+	bg := &bsonGroup{}
+	if err := raw.Unmarshal(bg); err != nil {
+		return err
+	}
+	g.Field = bg.Field
+	g.ButtonText = bg.ButtonText
+
+	var err error
+	g.Fields, err = unmarshalBSONFields(bg.Fields)
+	return err
+}
@@ -0,0 +1,137 @@
+package tyform
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/validator.v2"
+	. "testing"
+)
+
+var _ FieldInterface = (*Group)(nil)
+
+func TestGroup(t *T) {
+	// there is at least 1 field required
+	assert.NotNil(t, validator.Validate(&Group{
+		Field: randField(TypeGroup),
+	}))
+
+	// nested fields are validated
+	assert.NotNil(t, validator.Validate(&Group{
+		Field: randField(TypeGroup),
+		Fields: []FieldInterface{
+			&OpinionScale{
+				Field: randField(TypeOpinionScale),
+				Steps: 12,
+			},
+		},
+	}))
+
+	assert.Nil(t, validator.Validate(&Group{
+		Field: randField(TypeGroup),
+		Fields: []FieldInterface{
+			&OpinionScale{
+				Field: randField(TypeOpinionScale),
+				Steps: 5,
+			},
+		},
+	}))
+
+	// groups cannot be nested
+	assert.NotNil(t, validator.Validate(&Group{
+		Field: randField(TypeGroup),
+		Fields: []FieldInterface{
+			&Group{
+				Field: randField(TypeGroup),
+				Fields: []FieldInterface{
+					&Statement{Field: randField(TypeStatement)},
+				},
+			},
+		},
+	}))
+
+	nf := &Form{}
+	fs := `{"title":"t","fields":[{"type":"group","question":"q","fields":[{"type":"group","question":"q","fields":[{"type":"statement","question":"q"}]}]}]}`
+	require.Nil(t, json.Unmarshal([]byte(fs), nf))
+	assert.NotNil(t, validator.Validate(nf))
+
+	// nil fields are invalid rather than panicking
+	assert.NotNil(t, validator.Validate(&Group{
+		Field:  randField(TypeGroup),
+		Fields: []FieldInterface{nil},
+	}))
+	assert.NotNil(t, validator.Validate(&Group{
+		Field:  randField(TypeGroup),
+		Fields: []FieldInterface{(*Statement)(nil)},
+	}))
+}
+
+func TestJSONGroup(t *T) {
+	f := &Form{
+		Fields: []FieldInterface{
+			&Group{
+				Field: Field{
+					Type: TypeGroup,
+				},
+				ButtonText: "Go",
+				Fields: []FieldInterface{
+					&YesNo{
+						Field: Field{
+							Type: TypeYesNo,
+						},
+					},
+					&Statement{
+						Field: Field{
+							Type: TypeStatement,
+						},
+					},
+				},
+			},
+		},
+	}
+	fs := `{"title":"","fields":[{"type":"group","question":"","button_text":"Go","fields":[{"type":"yes_no","question":""},{"type":"statement","question":""}]}]}`
+	j, err := json.Marshal(f)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	nf := &Form{}
+	err = json.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
+func TestBSONGroup(t *T) {
+	yn := &YesNo{
+		Field: Field{
+			Type: TypeYesNo,
+		},
+	}
+	g := &Group{
+		Field: Field{
+			Type: TypeGroup,
+		},
+		ButtonText: "Go",
+		Fields:     []FieldInterface{yn},
+	}
+	f := &Form{
+		Fields: []FieldInterface{g},
+	}
+	fexp := struct {
+		Title  string   `bson:"t"`
+		Fields []*Group `bson:"f"`
+	}{
+		Title:  "",
+		Fields: []*Group{g},
+	}
+	j, err := bson.Marshal(f)
+	require.Nil(t, err)
+	jexp, err := bson.Marshal(fexp)
+	require.Nil(t, err)
+	assert.Equal(t, string(jexp), string(j))
+
+	nf := &Form{}
+	err = bson.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
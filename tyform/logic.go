@@ -0,0 +1,270 @@
+package tyform
+
+import (
+	"errors"
+	"fmt"
+)
+
+// LogicOperator is how a LogicCondition compares a field's answer to its Value
+type LogicOperator string
+
+var (
+	LogicEquals      LogicOperator = "equal"
+	LogicNotEquals   LogicOperator = "not_equal"
+	LogicGreaterThan LogicOperator = "greater_than"
+	LogicLowerThan   LogicOperator = "lower_than"
+	LogicContains    LogicOperator = "contains"
+)
+
+// LogicCondition compares the answer of the field with the given Ref to Value.
+// Ref can also be the name of one of the Form's Hidden fields.
+type LogicCondition struct {
+	Ref   string        `json:"ref"                   bson:"r"              validate:"nonzero,max=128"`
+	Op    LogicOperator `json:"op"                    bson:"o"              validate:"validateLogicOperator"`
+	Value interface{}   `json:"value"                 bson:"v"`
+}
+
+// LogicTargetType describes what a LogicTarget's Ref refers to
+type LogicTargetType string
+
+var (
+	LogicTargetField    LogicTargetType = "field"
+	LogicTargetThankYou LogicTargetType = "thankyou"
+)
+
+// LogicTarget is where a LogicJump goes to, either a field or one of the
+// Form's ThankYouScreens, which ends the form
+type LogicTarget struct {
+	Type LogicTargetType `json:"type"                  bson:"t"              validate:"validateLogicTargetType"`
+	Ref  string          `json:"ref"                   bson:"r"              validate:"nonzero,max=128"`
+}
+
+// LogicJump jumps from the field with the ref From to To once that field is
+// answered, if all of the Conditions match. A LogicJump without Conditions
+// always jumps. Jumps are evaluated in order and the first matching one is
+// used, otherwise the form continues to the next field.
+type LogicJump struct {
+	From       string           `json:"from"                  bson:"f"              validate:"nonzero,max=128"`
+	Conditions []LogicCondition `json:"conditions,omitempty"  bson:"c,omitempty"    validate:"max=20"`
+	To         LogicTarget      `json:"to"                    bson:"t"`
+}
+
+// ErrLogicCycle is returned by ValidateLogic when the logic jumps can lead
+// back to a field that was already shown
+var ErrLogicCycle = errors.New("logic jumps contain a cycle")
+
+// flatFields returns all of the Form's fields in the order they're shown,
+// including the ones nested in Groups
+func (f *Form) flatFields() []FieldInterface {
+	var fields []FieldInterface
+	var add func([]FieldInterface)
+	add = func(fs []FieldInterface) {
+		for _, fi := range fs {
+			fields = append(fields, fi)
+			if g, ok := fi.(*Group); ok {
+				add(g.Fields)
+			}
+		}
+	}
+	add(f.Fields)
+	return fields
+}
+
+// logicGraph returns the fields in order and, for each of them, the indexes
+// of the fields that can be shown after it. An index of len(fields) means the
+// form ends.
+func (f *Form) logicGraph() ([]FieldInterface, [][]int) {
+	fields := f.flatFields()
+	refs := map[string]int{}
+	for i, fi := range fields {
+		if r := fi.GetRef(); r != "" {
+			refs[r] = i
+		}
+	}
+
+	end := len(fields)
+	next := make([][]int, len(fields))
+	always := make([]bool, len(fields))
+	for _, lj := range f.LogicJumps {
+		i, ok := refs[lj.From]
+		if !ok || always[i] {
+			continue
+		}
+		to := end
+		if lj.To.Type == LogicTargetField {
+			if to, ok = refs[lj.To.Ref]; !ok {
+				continue
+			}
+		}
+		next[i] = append(next[i], to)
+		always[i] = len(lj.Conditions) == 0
+	}
+	for i := range fields {
+		if !always[i] {
+			next[i] = append(next[i], i+1)
+		}
+	}
+	return fields, next
+}
+
+// LogicCycle returns the fields that form a cycle through the Form's
+// LogicJumps, in the order they're shown, or nil if there's no cycle
+func (f *Form) LogicCycle() []FieldInterface {
+	fields, next := f.logicGraph()
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(fields))
+	var path []int
+	var visit func(int) []int
+	visit = func(i int) []int {
+		state[i] = visiting
+		path = append(path, i)
+		for _, n := range next[i] {
+			if n == len(fields) {
+				continue
+			}
+			switch state[n] {
+			case visiting:
+				for j := range path {
+					if path[j] == n {
+						return path[j:]
+					}
+				}
+			case unvisited:
+				if c := visit(n); c != nil {
+					return c
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+		return nil
+	}
+
+	for i := range fields {
+		if state[i] != unvisited {
+			continue
+		}
+		if c := visit(i); c != nil {
+			cycle := make([]FieldInterface, len(c))
+			for j, k := range c {
+				cycle[j] = fields[k]
+			}
+			return cycle
+		}
+	}
+	return nil
+}
+
+// UnreachableFields returns the fields that can never be shown because the
+// Form's LogicJumps always skip over them
+func (f *Form) UnreachableFields() []FieldInterface {
+	fields, next := f.logicGraph()
+	if len(fields) == 0 {
+		return nil
+	}
+
+	seen := make([]bool, len(fields)+1)
+	stack := []int{0}
+	seen[0] = true
+	for len(stack) > 0 {
+		i := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if i == len(fields) {
+			continue
+		}
+		for _, n := range next[i] {
+			if !seen[n] {
+				seen[n] = true
+				stack = append(stack, n)
+			}
+		}
+	}
+
+	var unreachable []FieldInterface
+	for i, fi := range fields {
+		if !seen[i] {
+			unreachable = append(unreachable, fi)
+		}
+	}
+	return unreachable
+}
+
+// ValidateLogic validates the Form's LogicJumps. Every ref they use must
+// belong to exactly one field, hidden field (for conditions) or ThankYouScreen
+// (for targets). They cannot contain a cycle and they cannot make any fields
+// unreachable. It's separate from the validator tags since it needs to look at
+// the whole Form.
+func (f *Form) ValidateLogic() error {
+	refs := map[string]bool{}
+	for _, fi := range f.flatFields() {
+		r := fi.GetRef()
+		if r == "" {
+			continue
+		}
+		if refs[r] {
+			return fmt.Errorf("duplicate field ref %q", r)
+		}
+		refs[r] = true
+	}
+	screens := map[string]bool{}
+	for _, ty := range f.ThankYouScreens {
+		if ty.Ref == "" {
+			continue
+		}
+		if screens[ty.Ref] {
+			return fmt.Errorf("duplicate thank you screen ref %q", ty.Ref)
+		}
+		screens[ty.Ref] = true
+	}
+	hidden := map[string]bool{}
+	for _, h := range f.Hidden {
+		hidden[h] = true
+	}
+
+	for _, lj := range f.LogicJumps {
+		if !refs[lj.From] {
+			return fmt.Errorf("logic jump from unknown field ref %q", lj.From)
+		}
+		for _, c := range lj.Conditions {
+			if !refs[c.Ref] && !hidden[c.Ref] {
+				return fmt.Errorf("logic condition on unknown field ref %q", c.Ref)
+			}
+		}
+		if lj.To.Type == LogicTargetField && !refs[lj.To.Ref] {
+			return fmt.Errorf("logic jump to unknown field ref %q", lj.To.Ref)
+		}
+		if lj.To.Type == LogicTargetThankYou && !screens[lj.To.Ref] {
+			return fmt.Errorf("logic jump to unknown thank you screen ref %q", lj.To.Ref)
+		}
+	}
+
+	if c := f.LogicCycle(); c != nil {
+		return fmt.Errorf("%w: %s", ErrLogicCycle, logicRefs(c))
+	}
+	if u := f.UnreachableFields(); u != nil {
+		return fmt.Errorf("unreachable fields: %s", logicRefs(u))
+	}
+	return nil
+}
+
+// logicRefs returns a description of the fields for error messages, using
+// their ref or question if they don't have one
+func logicRefs(fields []FieldInterface) string {
+	var s string
+	for i, fi := range fields {
+		if i > 0 {
+			s += ", "
+		}
+		if r := fi.GetRef(); r != "" {
+			s += fmt.Sprintf("%q", r)
+		} else {
+			s += fmt.Sprintf("%q", fi.GetQuestion())
+		}
+	}
+	return s
+}
@@ -0,0 +1,167 @@
+package tyform
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/validator.v2"
+	. "testing"
+)
+
+func logicTestForm(jumps ...LogicJump) *Form {
+	return &Form{
+		FormMetadata: FormMetadata{
+			Title: "Logic",
+		},
+		Fields: []FieldInterface{
+			&YesNo{Field: Field{Type: TypeYesNo, Question: "Q1", Ref: "q1"}},
+			&Number{Field: Field{Type: TypeNumber, Question: "Q2", Ref: "q2"}},
+			&Group{
+				Field: Field{Type: TypeGroup, Question: "Q3", Ref: "q3"},
+				Fields: []FieldInterface{
+					&ShortText{Field: Field{Type: TypeShortText, Question: "Q4", Ref: "q4"}},
+				},
+			},
+			&Statement{Field: Field{Type: TypeStatement, Question: "Q5", Ref: "q5"}},
+		},
+		LogicJumps: jumps,
+	}
+}
+
+func TestJSONLogicJumps(t *T) {
+	f := logicTestForm(LogicJump{
+		From: "q2",
+		Conditions: []LogicCondition{
+			{Ref: "q1", Op: LogicEquals, Value: true},
+			{Ref: "q2", Op: LogicGreaterThan, Value: float64(5)},
+		},
+		To: LogicTarget{Type: LogicTargetField, Ref: "q5"},
+	})
+
+	fs := `{"title":"Logic","fields":[` +
+		`{"type":"yes_no","question":"Q1","ref":"q1"},` +
+		`{"type":"number","question":"Q2","ref":"q2"},` +
+		`{"type":"group","question":"Q3","ref":"q3","fields":[{"type":"short_text","question":"Q4","ref":"q4"}]},` +
+		`{"type":"statement","question":"Q5","ref":"q5"}],` +
+		`"logic_jumps":[{"from":"q2","conditions":[{"ref":"q1","op":"equal","value":true},{"ref":"q2","op":"greater_than","value":5}],"to":{"type":"field","ref":"q5"}}]}`
+	j, err := json.Marshal(f)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	nf := &Form{}
+	err = json.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
+func TestBSONLogicJumps(t *T) {
+	f := logicTestForm(LogicJump{
+		From: "q1",
+		Conditions: []LogicCondition{
+			{Ref: "q1", Op: LogicEquals, Value: false},
+		},
+		To: LogicTarget{Type: LogicTargetField, Ref: "q5"},
+	})
+	j, err := bson.Marshal(f)
+	require.Nil(t, err)
+
+	m := bson.M{}
+	require.Nil(t, bson.Unmarshal(j, &m))
+	assert.Equal(t, []interface{}{
+		bson.M{
+			"f": "q1",
+			"c": []interface{}{bson.M{"r": "q1", "o": "equal", "v": false}},
+			"t": bson.M{"t": "field", "r": "q5"},
+		},
+	}, m["lj"])
+
+	nf := &Form{}
+	err = bson.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
+func TestValidateLogic(t *T) {
+	to := func(ref string) LogicTarget {
+		return LogicTarget{Type: LogicTargetField, Ref: ref}
+	}
+	cond := []LogicCondition{{Ref: "q1", Op: LogicEquals, Value: true}}
+
+	f := logicTestForm(
+		LogicJump{From: "q1", Conditions: cond, To: to("q4")},
+		LogicJump{From: "q4", Conditions: cond, To: to("q5")},
+	)
+	assert.Nil(t, f.ValidateLogic())
+	assert.Nil(t, f.LogicCycle())
+	assert.Nil(t, f.UnreachableFields())
+	assert.Nil(t, validator.Validate(f))
+
+	// unknown refs
+	f = logicTestForm(LogicJump{From: "q9", To: to("q5")})
+	assert.NotNil(t, f.ValidateLogic())
+	f = logicTestForm(LogicJump{From: "q1", To: to("q9")})
+	assert.NotNil(t, f.ValidateLogic())
+	f = logicTestForm(LogicJump{
+		From:       "q1",
+		Conditions: []LogicCondition{{Ref: "q9", Op: LogicEquals}},
+		To:         to("q2"),
+	})
+	assert.NotNil(t, f.ValidateLogic())
+
+	// conditions can use hidden fields
+	f = logicTestForm(LogicJump{
+		From:       "q1",
+		Conditions: []LogicCondition{{Ref: "source", Op: LogicEquals, Value: "email"}},
+		To:         to("q5"),
+	})
+	assert.NotNil(t, f.ValidateLogic())
+	f.Hidden = []string{"source"}
+	assert.Nil(t, f.ValidateLogic())
+
+	// duplicate refs
+	f = logicTestForm()
+	f.Fields[1].(*Number).Ref = "q1"
+	assert.NotNil(t, f.ValidateLogic())
+
+	// jumping backwards is a cycle
+	f = logicTestForm(LogicJump{From: "q4", Conditions: cond, To: to("q2")})
+	c := f.LogicCycle()
+	require.Len(t, c, 3)
+	assert.Equal(t, "q2", c[0].GetRef())
+	assert.Equal(t, "q3", c[1].GetRef())
+	assert.Equal(t, "q4", c[2].GetRef())
+	err := f.ValidateLogic()
+	assert.True(t, errors.Is(err, ErrLogicCycle))
+
+	// an unconditional jump skips the fields in between
+	f = logicTestForm(LogicJump{From: "q1", To: to("q4")})
+	u := f.UnreachableFields()
+	require.Len(t, u, 2)
+	assert.Equal(t, "q2", u[0].GetRef())
+	assert.Equal(t, "q3", u[1].GetRef())
+	assert.NotNil(t, f.ValidateLogic())
+
+	// unless something else jumps to them
+	f = logicTestForm(
+		LogicJump{From: "q1", Conditions: cond, To: to("q2")},
+		LogicJump{From: "q1", To: to("q4")},
+	)
+	u = f.UnreachableFields()
+	require.Len(t, u, 0)
+	assert.Nil(t, f.ValidateLogic())
+}
+
+func TestLogicValidators(t *T) {
+	tags := "validateLogicOperator"
+	assert.Nil(t, validator.Valid(LogicContains, tags))
+	assert.NotNil(t, validator.Valid(LogicOperator(""), tags))
+	assert.NotNil(t, validator.Valid(LogicOperator("like"), tags))
+	assert.NotNil(t, validator.Valid("equal", tags))
+
+	tags = "validateLogicTargetType"
+	assert.Nil(t, validator.Valid(LogicTargetField, tags))
+	assert.NotNil(t, validator.Valid(LogicTargetType("page"), tags))
+	assert.NotNil(t, validator.Valid("field", tags))
+}
@@ -0,0 +1,48 @@
+package tyform
+
+import (
+	"sync"
+)
+
+var (
+	fieldTypesL sync.RWMutex
+	fieldTypes  = map[FieldType]func() FieldInterface{}
+)
+
+func init() {
+	RegisterFieldType(TypeStatement, func() FieldInterface { return &Statement{} })
+	RegisterFieldType(TypeOpinionScale, func() FieldInterface { return &OpinionScale{} })
+	RegisterFieldType(TypeMultipleChoice, func() FieldInterface { return &MultipleChoice{} })
+	RegisterFieldType(TypeYesNo, func() FieldInterface { return &YesNo{} })
+	RegisterFieldType(TypeShortText, func() FieldInterface { return &ShortText{} })
+	RegisterFieldType(TypeLongText, func() FieldInterface { return &LongText{} })
+	RegisterFieldType(TypeEmail, func() FieldInterface { return &Email{} })
+	RegisterFieldType(TypeWebsite, func() FieldInterface { return &Website{} })
+	RegisterFieldType(TypeNumber, func() FieldInterface { return &Number{} })
+	RegisterFieldType(TypeRating, func() FieldInterface { return &Rating{} })
+	RegisterFieldType(TypeDropdown, func() FieldInterface { return &Dropdown{} })
+	RegisterFieldType(TypePictureChoice, func() FieldInterface { return &PictureChoice{} })
+	RegisterFieldType(TypeDate, func() FieldInterface { return &Date{} })
+	RegisterFieldType(TypeLegal, func() FieldInterface { return &Legal{} })
+	RegisterFieldType(TypeFileUpload, func() FieldInterface { return &FileUpload{} })
+	RegisterFieldType(TypePayment, func() FieldInterface { return &Payment{} })
+	RegisterFieldType(TypeGroup, func() FieldInterface { return &Group{} })
+}
+
+// RegisterFieldType registers a function that returns an empty struct for the
+// given type of field. When a Form or Group is unmarshaled each field is
+// unmarshaled into the struct returned by the function registered for its
+// type. This can be used to support field types that aren't in this package or
+// to override the ones that are. The struct should embed Field with
+// `bson:",inline"` like the ones in this package.
+//
+// RegisterFieldType is usually called from an init function. It panics if fn
+// is nil.
+func RegisterFieldType(t FieldType, fn func() FieldInterface) {
+	if fn == nil {
+		panic("tyform: RegisterFieldType fn is nil")
+	}
+	fieldTypesL.Lock()
+	defer fieldTypesL.Unlock()
+	fieldTypes[t] = fn
+}
@@ -0,0 +1,71 @@
+package tyform
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
+	. "testing"
+)
+
+var typeTestCustom FieldType = "test_custom"
+
+// testCustom is a field type that's registered from outside of field.go
+type testCustom struct {
+	Field `bson:",inline"`
+	Color string `json:"color"                          bson:"c"`
+}
+
+func init() {
+	RegisterFieldType(typeTestCustom, func() FieldInterface { return &testCustom{} })
+}
+
+func TestRegisterFieldType(t *T) {
+	c := &testCustom{
+		Field: Field{
+			Type: typeTestCustom,
+		},
+		Color: "red",
+	}
+	f := &Form{
+		Fields: []FieldInterface{c},
+	}
+
+	fs := `{"title":"","fields":[{"type":"test_custom","question":"","color":"red"}]}`
+	j, err := json.Marshal(f)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	nf := &Form{}
+	err = json.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+
+	j, err = bson.Marshal(f)
+	require.Nil(t, err)
+	nf = &Form{}
+	err = bson.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+
+	// registered types are used inside of groups too
+	g := &Group{
+		Field: Field{
+			Type: TypeGroup,
+		},
+		Fields: []FieldInterface{c},
+	}
+	f = &Form{
+		Fields: []FieldInterface{g},
+	}
+	j, err = json.Marshal(f)
+	require.Nil(t, err)
+	nf = &Form{}
+	err = json.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+
+	assert.Panics(t, func() {
+		RegisterFieldType(typeTestCustom, nil)
+	})
+}
@@ -0,0 +1,22 @@
+package tyform
+
+// WelcomeScreen is shown before the first field of a Form
+type WelcomeScreen struct {
+	Title       string      `json:"title"                 bson:"t"              validate:"nonzero,max=512"`
+	Description string      `json:"description,omitempty" bson:"d,omitempty"    validate:"max=512"`
+	ButtonText  string      `json:"button_text,omitempty" bson:"b,omitempty"    validate:"max=128"`
+	Attachment  *Attachment `json:"attachment,omitempty"  bson:"att,omitempty"`
+}
+
+// ThankYouScreen is shown once a Form is submitted. A Form can have many of
+// them, in which case the first one is shown unless a LogicJump with a
+// LogicTargetThankYou target jumps to another one by its Ref. If RedirectURL
+// is set the respondent is sent there instead.
+type ThankYouScreen struct {
+	Ref         string      `json:"ref,omitempty"          bson:"r,omitempty"    validate:"max=128"`
+	Title       string      `json:"title"                  bson:"t"              validate:"nonzero,max=512"`
+	Description string      `json:"description,omitempty"  bson:"d,omitempty"    validate:"max=512"`
+	ButtonText  string      `json:"button_text,omitempty"  bson:"b,omitempty"    validate:"max=128"`
+	Attachment  *Attachment `json:"attachment,omitempty"   bson:"att,omitempty"`
+	RedirectURL string      `json:"redirect_url,omitempty" bson:"u,omitempty"    validate:"validateURL"`
+}
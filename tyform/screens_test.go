@@ -0,0 +1,112 @@
+package tyform
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/validator.v2"
+	. "testing"
+)
+
+func screensTestForm() *Form {
+	return &Form{
+		FormMetadata: FormMetadata{
+			Title: "Screens",
+		},
+		Fields: []FieldInterface{
+			&YesNo{Field: Field{Type: TypeYesNo, Question: "Q1", Ref: "q1"}},
+		},
+		WelcomeScreen: &WelcomeScreen{
+			Title:      "Hi",
+			ButtonText: "Start",
+			Attachment: &Attachment{Type: AttachmentImage, ImageID: "img"},
+		},
+		ThankYouScreens: []ThankYouScreen{
+			{Title: "Thanks"},
+			{
+				Ref:         "no",
+				Title:       "Sorry",
+				Description: "Maybe next time",
+				RedirectURL: "https://example.com/no",
+			},
+		},
+	}
+}
+
+func TestJSONScreens(t *T) {
+	f := screensTestForm()
+	fs := `{"title":"Screens","fields":[{"type":"yes_no","question":"Q1","ref":"q1"}],` +
+		`"welcome_screen":{"title":"Hi","button_text":"Start","attachment":{"type":"image","image_id":"img"}},` +
+		`"thankyou_screens":[{"title":"Thanks"},{"ref":"no","title":"Sorry","description":"Maybe next time","redirect_url":"https://example.com/no"}]}`
+	j, err := json.Marshal(f)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	nf := &Form{}
+	err = json.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
+func TestBSONScreens(t *T) {
+	f := screensTestForm()
+	j, err := bson.Marshal(f)
+	require.Nil(t, err)
+
+	m := bson.M{}
+	require.Nil(t, bson.Unmarshal(j, &m))
+	assert.Equal(t, bson.M{
+		"t":   "Hi",
+		"b":   "Start",
+		"att": bson.M{"t": "image", "i": "img"},
+	}, m["ws"])
+	assert.Equal(t, []interface{}{
+		bson.M{"t": "Thanks"},
+		bson.M{"r": "no", "t": "Sorry", "d": "Maybe next time", "u": "https://example.com/no"},
+	}, m["ty"])
+
+	nf := &Form{}
+	err = bson.Unmarshal(j, nf)
+	require.Nil(t, err)
+	assert.EqualValues(t, f, nf)
+}
+
+func TestValidateScreens(t *T) {
+	f := screensTestForm()
+	assert.Nil(t, validator.Validate(f))
+
+	f.WelcomeScreen = nil
+	assert.Nil(t, validator.Validate(f))
+
+	f.WelcomeScreen = &WelcomeScreen{}
+	assert.NotNil(t, validator.Validate(f))
+	f.WelcomeScreen = nil
+
+	f.ThankYouScreens[0].Title = ""
+	assert.NotNil(t, validator.Validate(f))
+
+	f = screensTestForm()
+	f.ThankYouScreens[1].RedirectURL = "example.com"
+	assert.NotNil(t, validator.Validate(f))
+}
+
+func TestLogicThankYouScreens(t *T) {
+	f := screensTestForm()
+	f.LogicJumps = []LogicJump{
+		{
+			From:       "q1",
+			Conditions: []LogicCondition{{Ref: "q1", Op: LogicEquals, Value: false}},
+			To:         LogicTarget{Type: LogicTargetThankYou, Ref: "no"},
+		},
+	}
+	assert.Nil(t, validator.Validate(f))
+	assert.Nil(t, f.ValidateLogic())
+
+	f.LogicJumps[0].To.Ref = "yes"
+	assert.NotNil(t, f.ValidateLogic())
+
+	f.ThankYouScreens[0].Ref = "no"
+	f.LogicJumps[0].To.Ref = "no"
+	assert.NotNil(t, f.ValidateLogic())
+}
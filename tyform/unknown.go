@@ -0,0 +1,186 @@
+package tyform
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopkg.in/mgo.v2/bson"
+	"reflect"
+)
+
+// UnknownField is used when unmarshaling a field whose type hasn't been
+// registered with RegisterFieldType. It keeps the raw JSON or BSON it was
+// unmarshaled from so that the type-specific properties aren't lost when the
+// field is marshaled again. If the common Field properties weren't changed the
+// raw payload is re-emitted as-is, otherwise the changed properties replace the
+// original ones and everything else is kept.
+//
+// When an UnknownField that was unmarshaled from JSON is marshaled to BSON its
+// JSON is stored in the "_json" key so it can be marshaled to JSON again after
+// being read back. The type-specific properties of an UnknownField that was
+// only ever BSON can't be converted to JSON, so MarshalJSON returns an error
+// for it rather than dropping them.
+type UnknownField struct {
+	Field `bson:",inline"`
+
+	// orig is the Field as it was unmarshaled, used to detect changes
+	orig    Field
+	rawJSON json.RawMessage
+	rawBSON *bson.Raw
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (u *UnknownField) UnmarshalJSON(b []byte) error {
+	f := Field{}
+	if err := json.Unmarshal(b, &f); err != nil {
+		return err
+	}
+	u.Field = f
+	u.orig = f
+	u.rawJSON = append(json.RawMessage(nil), b...)
+	return nil
+}
+
+// unknownJSONKey is the BSON key that the JSON of an UnknownField is stored in
+const unknownJSONKey = "_json"
+
+// MarshalJSON implements the json.Marshaler interface
+func (u *UnknownField) MarshalJSON() ([]byte, error) {
+	if u.rawJSON == nil {
+		extra, err := u.bsonExtra()
+		if err != nil {
+			return nil, err
+		}
+		if len(extra) > 0 {
+			return nil, fmt.Errorf("cannot marshal the properties of unknown field type %q from BSON to JSON", u.Type)
+		}
+		return json.Marshal(u.Field)
+	}
+	if reflect.DeepEqual(u.Field, u.orig) {
+		return u.rawJSON, nil
+	}
+
+	m := map[string]json.RawMessage{}
+	if err := json.Unmarshal(u.rawJSON, &m); err != nil {
+		return nil, err
+	}
+	// remove the original common properties since some of them might've been
+	// emptied and are now omitted
+	var om map[string]json.RawMessage
+	if err := remarshalJSON(u.orig, &om); err != nil {
+		return nil, err
+	}
+	for k := range om {
+		delete(m, k)
+	}
+	var fm map[string]json.RawMessage
+	if err := remarshalJSON(u.Field, &fm); err != nil {
+		return nil, err
+	}
+	for k, v := range fm {
+		m[k] = v
+	}
+	return json.Marshal(m)
+}
+
+func remarshalJSON(src, dst interface{}) error {
+	b, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, dst)
+}
+
+// SetBSON implements the bson.Setter interface
+func (u *UnknownField) SetBSON(raw bson.Raw) error {
+	f := Field{}
+	if err := raw.Unmarshal(&f); err != nil {
+		return err
+	}
+	var j struct {
+		JSON string `bson:"_json"`
+	}
+	if err := raw.Unmarshal(&j); err != nil {
+		return err
+	}
+	u.Field = f
+	u.orig = f
+	u.rawJSON = nil
+	if j.JSON != "" {
+		u.rawJSON = json.RawMessage(j.JSON)
+	}
+	u.rawBSON = &bson.Raw{
+		Kind: raw.Kind,
+		Data: append([]byte(nil), raw.Data...),
+	}
+	return nil
+}
+
+// GetBSON implements the bson.Getter interface
+func (u *UnknownField) GetBSON() (interface{}, error) {
+	if u.rawJSON == nil {
+		if u.rawBSON == nil {
+			return u.Field, nil
+		}
+		if reflect.DeepEqual(u.Field, u.orig) {
+			return *u.rawBSON, nil
+		}
+	}
+
+	var fd bson.RawD
+	if err := remarshalBSON(u.Field, &fd); err != nil {
+		return nil, err
+	}
+	extra, err := u.bsonExtra()
+	if err != nil {
+		return nil, err
+	}
+	d := make(bson.D, 0, len(fd)+len(extra)+1)
+	for _, e := range append(fd, extra...) {
+		d = append(d, bson.DocElem{Name: e.Name, Value: e.Value})
+	}
+	if u.rawJSON != nil {
+		j, err := u.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		d = append(d, bson.DocElem{Name: unknownJSONKey, Value: string(j)})
+	}
+	return d, nil
+}
+
+// bsonExtra returns the elements of the raw BSON that aren't common Field
+// properties
+func (u *UnknownField) bsonExtra() (bson.RawD, error) {
+	if u.rawBSON == nil {
+		return nil, nil
+	}
+	var rd bson.RawD
+	if err := u.rawBSON.Unmarshal(&rd); err != nil {
+		return nil, err
+	}
+	// skip the original common properties since some of them might've been
+	// emptied and are now omitted
+	var od bson.RawD
+	if err := remarshalBSON(u.orig, &od); err != nil {
+		return nil, err
+	}
+	skip := map[string]bool{unknownJSONKey: true}
+	for _, e := range od {
+		skip[e.Name] = true
+	}
+	var extra bson.RawD
+	for _, e := range rd {
+		if !skip[e.Name] {
+			extra = append(extra, e)
+		}
+	}
+	return extra, nil
+}
+
+func remarshalBSON(src, dst interface{}) error {
+	b, err := bson.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(b, dst)
+}
@@ -0,0 +1,132 @@
+package tyform
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
+	. "testing"
+)
+
+func TestJSONUnknownField(t *T) {
+	fs := `{"title":"t","fields":[{"type":"matrix","question":"Rate these","rows":["a","b"],"columns":{"x":1}}]}`
+	f := &Form{}
+	require.Nil(t, json.Unmarshal([]byte(fs), f))
+	require.Len(t, f.Fields, 1)
+	u, ok := f.Fields[0].(*UnknownField)
+	require.True(t, ok)
+	assert.Equal(t, FieldType("matrix"), u.GetType())
+	assert.Equal(t, "Rate these", u.GetQuestion())
+
+	j, err := json.Marshal(f)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	// changing a common property keeps the type-specific ones
+	u.Question = "Rate those"
+	u.Ref = "matrix"
+	j, err = json.Marshal(u)
+	require.Nil(t, err)
+	assert.Equal(t, `{"columns":{"x":1},"question":"Rate those","ref":"matrix","rows":["a","b"],"type":"matrix"}`, string(j))
+
+	// an UnknownField that wasn't unmarshaled is just a Field
+	j, err = json.Marshal(&UnknownField{Field: Field{Type: "matrix", Question: "q"}})
+	require.Nil(t, err)
+	assert.Equal(t, `{"type":"matrix","question":"q"}`, string(j))
+}
+
+func TestBSONUnknownField(t *T) {
+	fexp := bson.M{
+		"t": "t",
+		"f": []interface{}{
+			bson.M{
+				"t":  "matrix",
+				"q":  "Rate these",
+				"d":  "desc",
+				"rw": []interface{}{"a", "b"},
+				"c":  bson.M{"x": 1},
+			},
+		},
+	}
+	b, err := bson.Marshal(fexp)
+	require.Nil(t, err)
+	f := &Form{}
+	require.Nil(t, bson.Unmarshal(b, f))
+	require.Len(t, f.Fields, 1)
+	u, ok := f.Fields[0].(*UnknownField)
+	require.True(t, ok)
+	assert.Equal(t, "desc", u.GetDescription())
+
+	b2, err := bson.Marshal(f)
+	require.Nil(t, err)
+	m := bson.M{}
+	require.Nil(t, bson.Unmarshal(b2, &m))
+	assert.Equal(t, fexp["f"].([]interface{})[0], m["f"].([]interface{})[0])
+
+	u.Description = ""
+	u.Required = true
+	b2, err = bson.Marshal(f)
+	require.Nil(t, err)
+	m = bson.M{}
+	require.Nil(t, bson.Unmarshal(b2, &m))
+	assert.Equal(t, bson.M{
+		"t":   "matrix",
+		"q":   "Rate these",
+		"d":   "",
+		"req": true,
+		"rw":  []interface{}{"a", "b"},
+		"c":   bson.M{"x": 1},
+	}, m["f"].([]interface{})[0])
+}
+
+func TestUnknownFieldJSONBSONJSON(t *T) {
+	fs := `{"title":"t","fields":[{"type":"matrix","question":"Rate these","rows":["a","b"]}]}`
+	f := &Form{}
+	require.Nil(t, json.Unmarshal([]byte(fs), f))
+
+	b, err := bson.Marshal(f)
+	require.Nil(t, err)
+	m := bson.M{}
+	require.Nil(t, bson.Unmarshal(b, &m))
+	assert.Equal(t, bson.M{
+		"t":     "matrix",
+		"q":     "Rate these",
+		"d":     "",
+		"_json": `{"type":"matrix","question":"Rate these","rows":["a","b"]}`,
+	}, m["f"].([]interface{})[0])
+
+	nf := &Form{}
+	require.Nil(t, bson.Unmarshal(b, nf))
+	j, err := json.Marshal(nf)
+	require.Nil(t, err)
+	assert.Equal(t, fs, string(j))
+
+	// changes made while it was BSON are kept
+	nf.Fields[0].(*UnknownField).Question = "Rate those"
+	b, err = bson.Marshal(nf)
+	require.Nil(t, err)
+	nf = &Form{}
+	require.Nil(t, bson.Unmarshal(b, nf))
+	assert.Equal(t, "Rate those", nf.Fields[0].GetQuestion())
+	j, err = json.Marshal(nf)
+	require.Nil(t, err)
+	assert.Equal(t, `{"title":"t","fields":[{"question":"Rate those","rows":["a","b"],"type":"matrix"}]}`, string(j))
+}
+
+func TestUnknownFieldBSONToJSON(t *T) {
+	b, err := bson.Marshal(bson.M{"t": "matrix", "q": "Rate these", "rw": []string{"a"}})
+	require.Nil(t, err)
+	u := &UnknownField{}
+	require.Nil(t, bson.Unmarshal(b, u))
+	_, err = json.Marshal(u)
+	assert.NotNil(t, err)
+
+	// without any type-specific properties there's nothing to lose
+	b, err = bson.Marshal(bson.M{"t": "matrix", "q": "Rate these"})
+	require.Nil(t, err)
+	u = &UnknownField{}
+	require.Nil(t, bson.Unmarshal(b, u))
+	j, err := json.Marshal(u)
+	require.Nil(t, err)
+	assert.Equal(t, `{"type":"matrix","question":"Rate these"}`, string(j))
+}
@@ -5,6 +5,8 @@ import (
 	"github.com/levenlabs/golib/rpcutil"
 	"gopkg.in/validator.v2"
 	"net/url"
+	"reflect"
+	"regexp"
 )
 
 func init() {
@@ -12,6 +14,34 @@ func init() {
 
 	// validateURL validates that the field is a url
 	validator.SetValidationFunc("validateURL", validateURL)
+
+	// validateColor validates that the field is a hex color
+	validator.SetValidationFunc("validateColor", validateColor)
+
+	// validateRatingShape validates that the field is a known RatingShape
+	validator.SetValidationFunc("validateRatingShape", validateRatingShape)
+
+	// validateNumberRange validates that the field's MinValue isn't greater
+	// than its MaxValue
+	validator.SetValidationFunc("validateNumberRange", validateNumberRange)
+
+	// validateAttachmentType validates that the field is a known
+	// AttachmentType
+	validator.SetValidationFunc("validateAttachmentType", validateAttachmentType)
+
+	// validateLogicOperator validates that the field is a known LogicOperator
+	validator.SetValidationFunc("validateLogicOperator", validateLogicOperator)
+
+	// validateLogicTargetType validates that the field is a known
+	// LogicTargetType
+	validator.SetValidationFunc("validateLogicTargetType", validateLogicTargetType)
+
+	// validateHiddenFields validates that the field is a list of unique
+	// hidden field names
+	validator.SetValidationFunc("validateHiddenFields", validateHiddenFields)
+
+	// validateGroupFields validates that none of the fields are Groups
+	validator.SetValidationFunc("validateGroupFields", validateGroupFields)
 }
 
 func validateURL(v interface{}, _ string) error {
@@ -31,3 +61,143 @@ func validateURL(v interface{}, _ string) error {
 	}
 	return err
 }
+
+var colorRegex = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+func validateColor(v interface{}, _ string) error {
+	c, ok := v.(string)
+	if !ok {
+		return validator.ErrUnsupported
+	}
+	if c == "" {
+		return nil
+	}
+	if !colorRegex.MatchString(c) {
+		return errors.New("invalid hex color")
+	}
+	return nil
+}
+
+var ratingShapes = map[RatingShape]bool{
+	RatingStar:        true,
+	RatingHeart:       true,
+	RatingUser:        true,
+	RatingThumbsUp:    true,
+	RatingCrown:       true,
+	RatingCat:         true,
+	RatingDog:         true,
+	RatingCircle:      true,
+	RatingFlag:        true,
+	RatingDroplet:     true,
+	RatingTick:        true,
+	RatingLightbulb:   true,
+	RatingTrophy:      true,
+	RatingCloud:       true,
+	RatingThunderbolt: true,
+	RatingPencil:      true,
+	RatingSkull:       true,
+}
+
+func validateRatingShape(v interface{}, _ string) error {
+	s, ok := v.(RatingShape)
+	if !ok {
+		return validator.ErrUnsupported
+	}
+	if s == "" || ratingShapes[s] {
+		return nil
+	}
+	return errors.New("unknown rating shape")
+}
+
+func validateNumberRange(v interface{}, _ string) error {
+	r, ok := v.(NumberRange)
+	if !ok {
+		return validator.ErrUnsupported
+	}
+	if r.MinValue != nil && r.MaxValue != nil && *r.MinValue > *r.MaxValue {
+		return errors.New("min value is greater than max value")
+	}
+	return nil
+}
+
+func validateAttachmentType(v interface{}, _ string) error {
+	t, ok := v.(AttachmentType)
+	if !ok {
+		return validator.ErrUnsupported
+	}
+	if t != AttachmentImage {
+		return errors.New("unknown attachment type")
+	}
+	return nil
+}
+
+var logicOperators = map[LogicOperator]bool{
+	LogicEquals:      true,
+	LogicNotEquals:   true,
+	LogicGreaterThan: true,
+	LogicLowerThan:   true,
+	LogicContains:    true,
+}
+
+func validateLogicOperator(v interface{}, _ string) error {
+	o, ok := v.(LogicOperator)
+	if !ok {
+		return validator.ErrUnsupported
+	}
+	if !logicOperators[o] {
+		return errors.New("unknown logic operator")
+	}
+	return nil
+}
+
+var logicTargetTypes = map[LogicTargetType]bool{
+	LogicTargetField:    true,
+	LogicTargetThankYou: true,
+}
+
+func validateLogicTargetType(v interface{}, _ string) error {
+	t, ok := v.(LogicTargetType)
+	if !ok {
+		return validator.ErrUnsupported
+	}
+	if !logicTargetTypes[t] {
+		return errors.New("unknown logic target type")
+	}
+	return nil
+}
+
+var hiddenFieldRegex = regexp.MustCompile(`^[a-z0-9_]{1,64}$`)
+
+func validateHiddenFields(v interface{}, _ string) error {
+	hs, ok := v.([]string)
+	if !ok {
+		return validator.ErrUnsupported
+	}
+	seen := map[string]bool{}
+	for _, h := range hs {
+		if !hiddenFieldRegex.MatchString(h) {
+			return errors.New("invalid hidden field name")
+		}
+		if seen[h] {
+			return errors.New("duplicate hidden field name")
+		}
+		seen[h] = true
+	}
+	return nil
+}
+
+func validateGroupFields(v interface{}, _ string) error {
+	fs, ok := v.([]FieldInterface)
+	if !ok {
+		return validator.ErrUnsupported
+	}
+	for _, f := range fs {
+		if rv := reflect.ValueOf(f); f == nil || rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return errors.New("field is nil")
+		}
+		if _, ok := f.(*Group); ok || f.GetType() == TypeGroup {
+			return errors.New("groups cannot contain groups")
+		}
+	}
+	return nil
+}
@@ -13,3 +13,40 @@ func TestURL(t *T) {
 	assert.NotNil(t, validator.Valid(11111, tags))
 	assert.Nil(t, validator.Valid("", tags))
 }
+
+func TestColor(t *T) {
+	tags := "validateColor"
+	assert.Nil(t, validator.Valid("#4FB0AE", tags))
+	assert.Nil(t, validator.Valid("#fff", tags))
+	assert.Nil(t, validator.Valid("", tags))
+	assert.NotNil(t, validator.Valid("4FB0AE", tags))
+	assert.NotNil(t, validator.Valid("#4FB0A", tags))
+	assert.NotNil(t, validator.Valid("#GGGGGG", tags))
+	assert.NotNil(t, validator.Valid(11111, tags))
+}
+
+func TestAttachmentType(t *T) {
+	tags := "validateAttachmentType"
+	assert.Nil(t, validator.Valid(AttachmentImage, tags))
+	assert.NotNil(t, validator.Valid(AttachmentType(""), tags))
+	assert.NotNil(t, validator.Valid(AttachmentType("video"), tags))
+	assert.NotNil(t, validator.Valid("image", tags))
+}
+
+func TestRatingShape(t *T) {
+	tags := "validateRatingShape"
+	assert.Nil(t, validator.Valid(RatingStar, tags))
+	assert.Nil(t, validator.Valid(RatingShape(""), tags))
+	assert.NotNil(t, validator.Valid(RatingShape("square"), tags))
+	assert.NotNil(t, validator.Valid("star", tags))
+}
+
+func TestHiddenFieldNames(t *T) {
+	tags := "validateHiddenFields"
+	assert.Nil(t, validator.Valid([]string{"user_id", "source2"}, tags))
+	assert.Nil(t, validator.Valid([]string(nil), tags))
+	assert.NotNil(t, validator.Valid([]string{"user_id", "user_id"}, tags))
+	assert.NotNil(t, validator.Valid([]string{"User"}, tags))
+	assert.NotNil(t, validator.Valid([]string{""}, tags))
+	assert.NotNil(t, validator.Valid("user_id", tags))
+}